@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/database"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/export"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export users|bookings|booth_checkins|stamp_redemptions",
+	Short: "Export data as CSV or XLSX",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("expect 1 argument: users|bookings|booth_checkins|stamp_redemptions")
+		}
+
+		cfg, err := getConfigFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		formatFlag, _ := cmd.Flags().GetString("format")
+		format := export.Format(strings.ToLower(formatFlag))
+		out, _ := cmd.Flags().GetString("out")
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		participantType, _ := cmd.Flags().GetString("participant-type")
+
+		kind := models.ExportKind(args[0])
+		filter, err := usecases.NewExportFilter(from, to, participantType)
+		if err != nil {
+			return err
+		}
+
+		exportUsecase := usecases.NewExportUsecase(repositories.NewExportRepo(database.NewPostgresDB(cfg.Database())))
+		if err := exportUsecase.ValidateExport(kind, filter); err != nil {
+			return err
+		}
+
+		if out == "" {
+			out = fmt.Sprintf("%s-%s.%s", kind, time.Now().Format("20060102-150405"), format)
+		}
+
+		var dst io.Writer = os.Stdout
+		if out != "-" {
+			file, err := os.Create(out)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			dst = file
+		}
+
+		w, err := export.NewWriter(format, dst)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		if err := exportUsecase.Export(ctx, kind, filter, w); err != nil {
+			w.Abort()
+			return err
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+
+		if out != "-" {
+			log.Printf("Exported %s to %s", kind, out)
+		}
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().String("format", "csv", "output format (csv, xlsx)")
+	exportCmd.Flags().String("out", "", "output file, - for stdout (default <kind>-<timestamp>.<format>)")
+	exportCmd.Flags().String("from", "", "include rows from this date (YYYY-MM-DD, Asia/Bangkok)")
+	exportCmd.Flags().String("to", "", "include rows up to this date (YYYY-MM-DD, Asia/Bangkok)")
+	exportCmd.Flags().String("participant-type", "", "only include users of this participant type")
+}
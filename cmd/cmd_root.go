@@ -34,7 +34,7 @@ var RootCmd = &cobra.Command{
 
 func init() {
 	RootCmd.PersistentFlags().String("env-file", "", "environment file")
-	RootCmd.AddCommand(serveCmd, migrateCmd, seedCmd)
+	RootCmd.AddCommand(serveCmd, migrateCmd, seedCmd, staffCmd, exportCmd, sweepCmd)
 }
 
 func setConfigToCmd(cmd *cobra.Command, cfg config.Config) {
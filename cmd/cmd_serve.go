@@ -2,12 +2,15 @@ package cmd
 
 import (
 	"context"
-	"log"
+	"log/slog"
+	"os"
 	"time"
 
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/migrations"
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/server"
 	"github.com/esc-chula/intania-openhouse-2026-api/pkg/database"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/logger"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/tracing"
 	"github.com/pressly/goose/v3"
 	"github.com/spf13/cobra"
 )
@@ -20,6 +23,23 @@ var serveCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if err := cfg.Token().Validate(cfg.App().IsProduction); err != nil {
+			return err
+		}
+
+		slog.SetDefault(logger.New(os.Stdout, !cfg.App().IsProduction))
+
+		shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing())
+		if err != nil {
+			return err
+		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTracing(ctx); err != nil {
+				slog.Error("failed to flush traces", "error", err)
+			}
+		}()
 
 		db := database.NewPostgresDB(cfg.Database())
 
@@ -41,7 +61,7 @@ var serveCmd = &cobra.Command{
 			return err
 		}
 
-		log.Printf("Listening on address %s", cfg.App().Address)
+		slog.Info("listening", "address", cfg.App().Address)
 
 		return server.InitServer(cfg, db)
 	},
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/database"
+	"github.com/spf13/cobra"
+)
+
+var staffCmd = &cobra.Command{
+	Use:   "staff grant|revoke <email> <role>",
+	Short: "Grant or revoke staff roles (admin, workshop_host, booth_operator, registration_desk)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 3 {
+			return errors.New("expect 3 arguments: grant|revoke <email> <role>")
+		}
+
+		cfg, err := getConfigFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+		db := database.NewPostgresDB(cfg.Database())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		staffUsecase := usecases.NewStaffUsecase(repositories.NewStaffRepo(db))
+		email, role := args[1], models.StaffRole(args[2])
+
+		switch args[0] {
+		case "grant":
+			if _, err := staffUsecase.AssignRole(ctx, email, role); err != nil {
+				return err
+			}
+			log.Printf("Granted %s to %s", role, email)
+		case "revoke":
+			if err := staffUsecase.RevokeRole(ctx, email, role); err != nil {
+				return err
+			}
+			log.Printf("Revoked %s from %s", role, email)
+		default:
+			return errors.New("invalid staff argument.")
+		}
+		return nil
+	},
+}
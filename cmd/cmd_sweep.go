@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/database"
+	"github.com/spf13/cobra"
+)
+
+var sweepCmd = &cobra.Command{
+	Use:   "sweep",
+	Short: "Mark Confirmed bookings of ended workshops as Absent once, the server also does it periodically",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := getConfigFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+		db := database.NewPostgresDB(cfg.Database())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		sweepUsecase := usecases.NewSweepUsecase(repositories.NewBookingRepo(db), cfg.Sweeper())
+		n, err := sweepUsecase.SweepNoShows(ctx)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Marked %d bookings as Absent", n)
+		return nil
+	},
+}
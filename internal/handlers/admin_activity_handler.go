@@ -0,0 +1,335 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/middlewares"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/myValidator"
+)
+
+var (
+	ErrInvalidActivityTime         = huma.Error400BadRequest("end time must be after start time")
+	ErrActivityConstraintViolation = huma.Error400BadRequest("activity violates a database constraint")
+)
+
+type adminActivityHandler struct {
+	activityUsecase usecases.ActivityUsecase
+	mid             middlewares.Middleware
+}
+
+func InitAdminActivityHandler(adminGroup huma.API, activityUsecase usecases.ActivityUsecase, mid middlewares.Middleware) {
+	handler := &adminActivityHandler{
+		activityUsecase: activityUsecase,
+		mid:             mid,
+	}
+	adminActivityTag := "admin-activity"
+
+	huma.Get(adminGroup, "/activities", handler.ListActivities, func(o *huma.Operation) {
+		o.Summary = "List activities for management"
+		o.Description = "Retrieve activities sorted by start time, archived activities are included with `include_archived`. Requires `admin` role."
+		o.Tags = []string{adminActivityTag}
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Post(adminGroup, "/activities", handler.CreateActivity, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(createActivityErrorList)
+		o.Summary = "Create an activity"
+		o.Description = "Create an activity. Requires `admin` role." + errDoc
+		o.DefaultStatus = 201
+		o.Tags = []string{adminActivityTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Post(adminGroup, "/activities/import", handler.ImportActivities, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(importActivitiesErrorList)
+		o.Summary = "Bulk import activities"
+		o.Description = "Create many activities at once, either all activities are created or none. Validation errors are prefixed with the index of the rejected item. Requires `admin` role." + errDoc
+		o.DefaultStatus = 201
+		o.Tags = []string{adminActivityTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Patch(adminGroup, "/activities/{id}", handler.UpdateActivity, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(updateActivityErrorList)
+		o.Summary = "Update an activity"
+		o.Description = "Partially update an activity, only provided fields are changed. Requires `admin` role." + errDoc
+		o.Tags = []string{adminActivityTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Post(adminGroup, "/activities/{id}/archive", handler.ArchiveActivity, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(archiveActivityErrorList)
+		o.Summary = "Archive an activity"
+		o.Description = "Hide an activity from `GET /activities`. Requires `admin` role." + errDoc
+		o.DefaultStatus = 204
+		o.Tags = []string{adminActivityTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+}
+
+var (
+	createActivityErrorList   = []huma.StatusError{ErrInvalidEventDate, ErrInvalidTimeOfDay, ErrInvalidActivityTime, ErrActivityConstraintViolation, ErrInternalServerError()}
+	importActivitiesErrorList = []huma.StatusError{ErrEmptyImport, ErrInvalidEventDate, ErrInvalidTimeOfDay, ErrInvalidActivityTime, ErrActivityConstraintViolation, ErrInternalServerError()}
+	updateActivityErrorList   = []huma.StatusError{ErrActivityNotFound, ErrNothingToUpdate, ErrInvalidEventDate, ErrInvalidTimeOfDay, ErrInvalidActivityTime, ErrActivityConstraintViolation, ErrInternalServerError()}
+	archiveActivityErrorList  = []huma.StatusError{ErrActivityNotFound, ErrInternalServerError()}
+)
+
+type AdminActivityItem struct {
+	ID           int64      `json:"id"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	StartTime    time.Time  `json:"start_time"`
+	EndTime      time.Time  `json:"end_time"`
+	EventDate    string     `json:"event_date"`
+	BuildingName *string    `json:"building_name"`
+	Floor        *string    `json:"floor"`
+	RoomName     *string    `json:"room_name"`
+	Image        *string    `json:"image"`
+	Link         *string    `json:"link"`
+	ArchivedAt   *time.Time `json:"archived_at,omitempty"`
+}
+
+func toAdminActivityItem(a *models.Activity) AdminActivityItem {
+	return AdminActivityItem{
+		ID:           a.ID,
+		Title:        a.Title,
+		Description:  a.Description,
+		StartTime:    a.StartTime,
+		EndTime:      a.EndTime,
+		EventDate:    a.EventDate,
+		BuildingName: a.BuildingName,
+		Floor:        a.Floor,
+		RoomName:     a.RoomName,
+		Image:        a.Image,
+		Link:         a.Link,
+		ArchivedAt:   a.ArchivedAt,
+	}
+}
+
+type AdminActivityInput struct {
+	Title        string  `json:"title"                   minLength:"1"`
+	Description  string  `json:"description"`
+	EventDate    string  `json:"event_date"              doc:"date in format 2026-03-28"`
+	StartTime    string  `json:"start_time"              doc:"time in format 13:00"`
+	EndTime      string  `json:"end_time"                doc:"time in format 15:30"`
+	BuildingName *string `json:"building_name,omitempty"`
+	Floor        *string `json:"floor,omitempty"`
+	RoomName     *string `json:"room_name,omitempty"`
+	Image        *string `json:"image,omitempty"`
+	Link         *string `json:"link,omitempty"`
+}
+
+func (in AdminActivityInput) toModel() (*models.Activity, error) {
+	startTime, err := myValidator.ParseTimeOfDay(in.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	endTime, err := myValidator.ParseTimeOfDay(in.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Activity{
+		Title:        in.Title,
+		Description:  in.Description,
+		EventDate:    in.EventDate,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		BuildingName: in.BuildingName,
+		Floor:        in.Floor,
+		RoomName:     in.RoomName,
+		Image:        in.Image,
+		Link:         in.Link,
+	}, nil
+}
+
+type AdminListActivitiesRequest struct {
+	IncludeArchived bool `query:"include_archived" default:"false"`
+}
+
+type AdminListActivitiesResponse struct {
+	Body AdminListActivitiesResponseBody
+}
+
+type AdminListActivitiesResponseBody struct {
+	Activities []AdminActivityItem `json:"activities"`
+}
+
+func (h *adminActivityHandler) ListActivities(ctx context.Context, input *AdminListActivitiesRequest) (*AdminListActivitiesResponse, error) {
+	activities, err := h.activityUsecase.ListActivities(ctx, models.ActivityFilter{
+		SortBy:          "start_time",
+		Order:           "asc",
+		IncludeArchived: input.IncludeArchived,
+	})
+	if err != nil {
+		return nil, ErrInternalServerError(err)
+	}
+
+	return &AdminListActivitiesResponse{
+		Body: AdminListActivitiesResponseBody{
+			Activities: toAdminActivityItems(activities),
+		},
+	}, nil
+}
+
+type CreateActivityRequest struct {
+	Body AdminActivityInput
+}
+
+type CreateActivityResponse struct {
+	Body AdminActivityItem
+}
+
+func (h *adminActivityHandler) CreateActivity(ctx context.Context, input *CreateActivityRequest) (*CreateActivityResponse, error) {
+	activity, err := input.Body.toModel()
+	if err != nil {
+		return nil, ErrInvalidTimeOfDay
+	}
+
+	if err := h.activityUsecase.CreateActivity(ctx, activity); err != nil {
+		return nil, mapImportErr(err, mapActivityWriteErr)
+	}
+
+	return &CreateActivityResponse{
+		Body: toAdminActivityItem(activity),
+	}, nil
+}
+
+type ImportActivitiesRequest struct {
+	Body struct {
+		Activities []AdminActivityInput `json:"activities" minItems:"1"`
+	}
+}
+
+type ImportActivitiesResponse struct {
+	Body AdminListActivitiesResponseBody
+}
+
+func (h *adminActivityHandler) ImportActivities(ctx context.Context, input *ImportActivitiesRequest) (*ImportActivitiesResponse, error) {
+	activities := make([]*models.Activity, 0, len(input.Body.Activities))
+	for i, a := range input.Body.Activities {
+		activity, err := a.toModel()
+		if err != nil {
+			return nil, mapImportErr(&usecases.ImportItemError{Index: i, Err: err}, mapActivityWriteErr)
+		}
+		activities = append(activities, activity)
+	}
+
+	if err := h.activityUsecase.ImportActivities(ctx, activities); err != nil {
+		return nil, mapImportErr(err, mapActivityWriteErr)
+	}
+
+	return &ImportActivitiesResponse{
+		Body: AdminListActivitiesResponseBody{
+			Activities: toAdminActivityItems(activities),
+		},
+	}, nil
+}
+
+type UpdateActivityRequest struct {
+	ID   int64 `path:"id"`
+	Body struct {
+		Title        *string `json:"title,omitempty"         minLength:"1"`
+		Description  *string `json:"description,omitempty"`
+		EventDate    *string `json:"event_date,omitempty"    doc:"date in format 2026-03-28"`
+		StartTime    *string `json:"start_time,omitempty"    doc:"time in format 13:00"`
+		EndTime      *string `json:"end_time,omitempty"      doc:"time in format 15:30"`
+		BuildingName *string `json:"building_name,omitempty"`
+		Floor        *string `json:"floor,omitempty"`
+		RoomName     *string `json:"room_name,omitempty"`
+		Image        *string `json:"image,omitempty"`
+		Link         *string `json:"link,omitempty"`
+	}
+}
+
+type UpdateActivityResponse struct {
+	Body AdminActivityItem
+}
+
+func (h *adminActivityHandler) UpdateActivity(ctx context.Context, input *UpdateActivityRequest) (*UpdateActivityResponse, error) {
+	update := &models.ActivityOptional{
+		Title:        input.Body.Title,
+		Description:  input.Body.Description,
+		EventDate:    input.Body.EventDate,
+		BuildingName: input.Body.BuildingName,
+		Floor:        input.Body.Floor,
+		RoomName:     input.Body.RoomName,
+		Image:        input.Body.Image,
+		Link:         input.Body.Link,
+	}
+	if input.Body.StartTime != nil {
+		startTime, err := myValidator.ParseTimeOfDay(*input.Body.StartTime)
+		if err != nil {
+			return nil, ErrInvalidTimeOfDay
+		}
+		update.StartTime = &startTime
+	}
+	if input.Body.EndTime != nil {
+		endTime, err := myValidator.ParseTimeOfDay(*input.Body.EndTime)
+		if err != nil {
+			return nil, ErrInvalidTimeOfDay
+		}
+		update.EndTime = &endTime
+	}
+
+	activity, err := h.activityUsecase.UpdateActivity(ctx, input.ID, update)
+	if err != nil {
+		return nil, mapActivityWriteErr(err)
+	}
+
+	return &UpdateActivityResponse{
+		Body: toAdminActivityItem(activity),
+	}, nil
+}
+
+type ArchiveActivityRequest struct {
+	ID int64 `path:"id"`
+}
+
+type ArchiveActivityResponse struct {
+	Body *struct{}
+}
+
+func (h *adminActivityHandler) ArchiveActivity(ctx context.Context, input *ArchiveActivityRequest) (*ArchiveActivityResponse, error) {
+	if err := h.activityUsecase.ArchiveActivity(ctx, input.ID); err != nil {
+		return nil, mapActivityWriteErr(err)
+	}
+
+	return &ArchiveActivityResponse{}, nil
+}
+
+func toAdminActivityItems(activities []*models.Activity) []AdminActivityItem {
+	items := make([]AdminActivityItem, 0, len(activities))
+	for _, a := range activities {
+		items = append(items, toAdminActivityItem(a))
+	}
+	return items
+}
+
+func mapActivityWriteErr(err error) huma.StatusError {
+	switch err {
+	case repositories.ErrActivityNotFound:
+		return ErrActivityNotFound
+	case repositories.ErrActivityConstraintViolation:
+		return ErrActivityConstraintViolation
+	case myValidator.ErrInvalidEventDate:
+		return ErrInvalidEventDate
+	case myValidator.ErrInvalidTimeOfDay:
+		return ErrInvalidTimeOfDay
+	case usecases.ErrInvalidActivityTime:
+		return ErrInvalidActivityTime
+	case usecases.ErrNothingToUpdate:
+		return ErrNothingToUpdate
+	default:
+		return ErrInternalServerError(err)
+	}
+}
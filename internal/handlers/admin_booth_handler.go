@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/middlewares"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/myValidator"
+)
+
+var (
+	ErrInvalidBoothCategory      = huma.Error400BadRequest("invalid booth category")
+	ErrInvalidBoothCheckInCode   = huma.Error400BadRequest("booth check-in code must be a uuid")
+	ErrDuplicateBoothCheckInCode = huma.Error400BadRequest("duplicate booth check-in code")
+	ErrBoothCheckInCodeTaken     = huma.Error409Conflict("booth check-in code already in use")
+	ErrEmptyImport               = huma.Error400BadRequest("nothing to import")
+)
+
+type adminBoothHandler struct {
+	boothUsecase usecases.BoothUsecase
+	mid          middlewares.Middleware
+}
+
+func InitAdminBoothHandler(adminGroup huma.API, boothUsecase usecases.BoothUsecase, mid middlewares.Middleware) {
+	handler := &adminBoothHandler{
+		boothUsecase: boothUsecase,
+		mid:          mid,
+	}
+	adminBoothTag := "admin-booth"
+
+	huma.Get(adminGroup, "/booths", handler.ListBooths, func(o *huma.Operation) {
+		o.Summary = "List booths with check-in codes"
+		o.Description = "Retrieve every booth including its check-in code. Requires `booth_operator` role."
+		o.Tags = []string{adminBoothTag}
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleBoothOperator)}
+	})
+
+	huma.Post(adminGroup, "/booths", handler.CreateBooth, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(createBoothErrorList)
+		o.Summary = "Create a booth"
+		o.Description = "Create a booth. A check-in code is generated when none is given. Requires `admin` role." + errDoc
+		o.DefaultStatus = 201
+		o.Tags = []string{adminBoothTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Post(adminGroup, "/booths/import", handler.ImportBooths, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(importBoothsErrorList)
+		o.Summary = "Bulk import booths"
+		o.Description = "Create many booths at once, either all booths are created or none. Validation errors are prefixed with the index of the rejected item. Requires `admin` role." + errDoc
+		o.DefaultStatus = 201
+		o.Tags = []string{adminBoothTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Patch(adminGroup, "/booths/{id}", handler.UpdateBooth, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(updateBoothErrorList)
+		o.Summary = "Update a booth"
+		o.Description = "Partially update a booth, only provided fields are changed. Requires `admin` role." + errDoc
+		o.Tags = []string{adminBoothTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Post(adminGroup, "/booths/{id}/archive", handler.ArchiveBooth, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(archiveBoothErrorList)
+		o.Summary = "Archive a booth"
+		o.Description = "Hide a booth and reject further check-ins. Existing check-ins and stamps are kept. Requires `admin` role." + errDoc
+		o.DefaultStatus = 204
+		o.Tags = []string{adminBoothTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+}
+
+var (
+	createBoothErrorList  = []huma.StatusError{ErrInvalidBoothCategory, ErrInvalidBoothCheckInCode, ErrBoothCheckInCodeTaken, ErrInternalServerError()}
+	importBoothsErrorList = []huma.StatusError{ErrEmptyImport, ErrInvalidBoothCategory, ErrInvalidBoothCheckInCode, ErrDuplicateBoothCheckInCode, ErrBoothCheckInCodeTaken, ErrInternalServerError()}
+	updateBoothErrorList  = []huma.StatusError{ErrBoothNotFound, ErrNothingToUpdate, ErrInvalidBoothCategory, ErrInvalidBoothCheckInCode, ErrBoothCheckInCodeTaken, ErrInternalServerError()}
+	archiveBoothErrorList = []huma.StatusError{ErrBoothNotFound, ErrInternalServerError()}
+)
+
+type AdminBoothItem struct {
+	ID          int64                `json:"id"`
+	Name        string               `json:"name"`
+	Category    models.BoothCategory `json:"category"              enum:"department,club,exhibition"`
+	CheckInCode string               `json:"check_in_code"         doc:"Code to encode in the booth QR, formatted as B-<uuid>"`
+	ArchivedAt  *time.Time           `json:"archived_at,omitempty"`
+}
+
+func toAdminBoothItem(b *models.Booth) AdminBoothItem {
+	return AdminBoothItem{
+		ID:          b.ID,
+		Name:        b.Name,
+		Category:    b.Category,
+		CheckInCode: usecases.PrefixBooth + b.CheckInCode,
+		ArchivedAt:  b.ArchivedAt,
+	}
+}
+
+type AdminBoothInput struct {
+	Name        string               `json:"name"          minLength:"1"`
+	Category    models.BoothCategory `json:"category"      enum:"department,club,exhibition"`
+	CheckInCode string               `json:"check_in_code" required:"false" doc:"UUID without the B- prefix, generated when empty"`
+}
+
+func (in AdminBoothInput) toModel() *models.Booth {
+	return &models.Booth{
+		Name:        in.Name,
+		Category:    in.Category,
+		CheckInCode: in.CheckInCode,
+	}
+}
+
+type AdminListBoothsRequest struct {
+	IncludeArchived bool `query:"include_archived" default:"false"`
+}
+
+type AdminListBoothsResponse struct {
+	Body AdminListBoothsResponseBody
+}
+
+type AdminListBoothsResponseBody struct {
+	Booths []AdminBoothItem `json:"booths"`
+}
+
+func (h *adminBoothHandler) ListBooths(ctx context.Context, input *AdminListBoothsRequest) (*AdminListBoothsResponse, error) {
+	booths, err := h.boothUsecase.ListBooths(ctx, input.IncludeArchived)
+	if err != nil {
+		return nil, ErrInternalServerError(err)
+	}
+
+	items := make([]AdminBoothItem, 0, len(booths))
+	for _, b := range booths {
+		items = append(items, toAdminBoothItem(b))
+	}
+
+	return &AdminListBoothsResponse{
+		Body: AdminListBoothsResponseBody{
+			Booths: items,
+		},
+	}, nil
+}
+
+type CreateBoothRequest struct {
+	Body AdminBoothInput
+}
+
+type CreateBoothResponse struct {
+	Body AdminBoothItem
+}
+
+func (h *adminBoothHandler) CreateBooth(ctx context.Context, input *CreateBoothRequest) (*CreateBoothResponse, error) {
+	booth := input.Body.toModel()
+	if err := h.boothUsecase.CreateBooth(ctx, booth); err != nil {
+		return nil, mapImportErr(err, mapBoothWriteErr)
+	}
+
+	return &CreateBoothResponse{
+		Body: toAdminBoothItem(booth),
+	}, nil
+}
+
+type ImportBoothsRequest struct {
+	Body struct {
+		Booths []AdminBoothInput `json:"booths" minItems:"1"`
+	}
+}
+
+type ImportBoothsResponse struct {
+	Body AdminListBoothsResponseBody
+}
+
+func (h *adminBoothHandler) ImportBooths(ctx context.Context, input *ImportBoothsRequest) (*ImportBoothsResponse, error) {
+	booths := make([]*models.Booth, 0, len(input.Body.Booths))
+	for _, b := range input.Body.Booths {
+		booths = append(booths, b.toModel())
+	}
+
+	if err := h.boothUsecase.ImportBooths(ctx, booths); err != nil {
+		return nil, mapImportErr(err, mapBoothWriteErr)
+	}
+
+	items := make([]AdminBoothItem, 0, len(booths))
+	for _, b := range booths {
+		items = append(items, toAdminBoothItem(b))
+	}
+
+	return &ImportBoothsResponse{
+		Body: AdminListBoothsResponseBody{
+			Booths: items,
+		},
+	}, nil
+}
+
+type UpdateBoothRequest struct {
+	ID   int64 `path:"id"`
+	Body struct {
+		Name        *string               `json:"name,omitempty"          minLength:"1"`
+		Category    *models.BoothCategory `json:"category,omitempty"      enum:"department,club,exhibition"`
+		CheckInCode *string               `json:"check_in_code,omitempty" doc:"UUID without the B- prefix, replacing it invalidates the printed QR code"`
+	}
+}
+
+type UpdateBoothResponse struct {
+	Body AdminBoothItem
+}
+
+func (h *adminBoothHandler) UpdateBooth(ctx context.Context, input *UpdateBoothRequest) (*UpdateBoothResponse, error) {
+	booth, err := h.boothUsecase.UpdateBooth(ctx, input.ID, &models.BoothOptional{
+		Name:        input.Body.Name,
+		Category:    input.Body.Category,
+		CheckInCode: input.Body.CheckInCode,
+	})
+	if err != nil {
+		return nil, mapBoothWriteErr(err)
+	}
+
+	return &UpdateBoothResponse{
+		Body: toAdminBoothItem(booth),
+	}, nil
+}
+
+type ArchiveBoothRequest struct {
+	ID int64 `path:"id"`
+}
+
+type ArchiveBoothResponse struct {
+	Body *struct{}
+}
+
+func (h *adminBoothHandler) ArchiveBooth(ctx context.Context, input *ArchiveBoothRequest) (*ArchiveBoothResponse, error) {
+	if err := h.boothUsecase.ArchiveBooth(ctx, input.ID); err != nil {
+		return nil, mapBoothWriteErr(err)
+	}
+
+	return &ArchiveBoothResponse{}, nil
+}
+
+func mapBoothWriteErr(err error) huma.StatusError {
+	switch err {
+	case repositories.ErrBoothNotFound:
+		return ErrBoothNotFound
+	case repositories.ErrBoothCheckInCodeTaken:
+		return ErrBoothCheckInCodeTaken
+	case myValidator.ErrInvalidBoothCategory:
+		return ErrInvalidBoothCategory
+	case usecases.ErrInvalidBoothCheckInCode:
+		return ErrInvalidBoothCheckInCode
+	case usecases.ErrDuplicateBoothCheckInCode:
+		return ErrDuplicateBoothCheckInCode
+	case usecases.ErrNothingToUpdate:
+		return ErrNothingToUpdate
+	default:
+		return ErrInternalServerError(err)
+	}
+}
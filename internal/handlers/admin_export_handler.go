@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/middlewares"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/export"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/myValidator"
+)
+
+var (
+	ErrInvalidExportKind = huma.Error400BadRequest("invalid export kind")
+	ErrInvalidExportDate = huma.Error400BadRequest("invalid export date, expected YYYY-MM-DD")
+)
+
+type adminExportHandler struct {
+	exportUsecase usecases.ExportUsecase
+	mid           middlewares.Middleware
+}
+
+func InitAdminExportHandler(adminGroup huma.API, exportUsecase usecases.ExportUsecase, mid middlewares.Middleware) {
+	handler := &adminExportHandler{
+		exportUsecase: exportUsecase,
+		mid:           mid,
+	}
+
+	huma.Get(adminGroup, "/exports/{kind}", handler.Export, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(exportErrorList)
+		o.Summary = "Export data as a spreadsheet"
+		o.Description = "Stream users (with flattened extra attributes), bookings with workshop info, booth check-ins or redeemed stamp posters as CSV or XLSX. " +
+			"`from` and `to` are inclusive dates in Asia/Bangkok matched against the registration, booking or check-in time, stamp redemptions ignore them. Requires `admin` role." + errDoc
+		o.Tags = []string{"admin"}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+		makeStreaming(o)
+	})
+}
+
+var exportErrorList = []huma.StatusError{ErrInvalidExportKind, ErrInvalidExportDate, ErrInvalidParticipantType, ErrInternalServerError()}
+
+type ExportRequest struct {
+	Kind            models.ExportKind `path:"kind"              enum:"users,bookings,booth_checkins,stamp_redemptions"`
+	Format          export.Format     `query:"format"           enum:"csv,xlsx"                                          default:"csv"`
+	From            string            `query:"from"             doc:"date in format 2026-03-28"`
+	To              string            `query:"to"               doc:"date in format 2026-03-29"`
+	ParticipantType string            `query:"participant_type"`
+}
+
+func (h *adminExportHandler) Export(ctx context.Context, input *ExportRequest) (*huma.StreamResponse, error) {
+	filter, err := usecases.NewExportFilter(input.From, input.To, input.ParticipantType)
+	if err != nil {
+		return nil, ErrInvalidExportDate
+	}
+	if err := h.exportUsecase.ValidateExport(input.Kind, filter); err != nil {
+		switch err {
+		case usecases.ErrInvalidExportKind:
+			return nil, ErrInvalidExportKind
+		case myValidator.ErrInvalidParticipantType:
+			return nil, ErrInvalidParticipantType
+		default:
+			return nil, ErrInternalServerError(err)
+		}
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", input.Kind, time.Now().Format("20060102-150405"), input.Format)
+
+	return &huma.StreamResponse{
+		Body: func(hctx huma.Context) {
+			hctx.SetHeader("Content-Type", export.ContentType(input.Format))
+			hctx.SetHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+			w, err := export.NewWriter(input.Format, hctx.BodyWriter())
+			if err != nil {
+				slog.ErrorContext(hctx.Context(), "export failed", "kind", input.Kind, "error", err)
+				return
+			}
+
+			// The status is already sent once rows are written, so a failure aborts the connection and
+			// the client sees a broken download instead of a file that looks complete
+			if err := h.exportUsecase.Export(hctx.Context(), input.Kind, filter, w); err != nil {
+				slog.ErrorContext(hctx.Context(), "export failed", "kind", input.Kind, "error", err)
+				w.Abort()
+				panic(http.ErrAbortHandler)
+			}
+			if err := w.Close(); err != nil {
+				slog.ErrorContext(hctx.Context(), "export failed", "kind", input.Kind, "error", err)
+			}
+		},
+	}, nil
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/middlewares"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
+)
+
+type adminStatsHandler struct {
+	statsUsecase usecases.StatsUsecase
+	mid          middlewares.Middleware
+}
+
+func InitAdminStatsHandler(adminGroup huma.API, statsUsecase usecases.StatsUsecase, mid middlewares.Middleware) {
+	handler := &adminStatsHandler{
+		statsUsecase: statsUsecase,
+		mid:          mid,
+	}
+
+	huma.Get(adminGroup, "/stats", handler.GetStats, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(getStatsErrorList)
+		o.Summary = "Get live event metrics"
+		o.Description = "Aggregated registrations, workshop fill, attendance, booth check-ins and stamp redemptions. " +
+			"Results are cached, `generated_at` tells when they were computed. Requires `admin` role." + errDoc
+		o.Tags = []string{"admin"}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+}
+
+var getStatsErrorList = []huma.StatusError{ErrInternalServerError()}
+
+type GetStatsResponse struct {
+	Body *models.EventStats
+}
+
+func (h *adminStatsHandler) GetStats(ctx context.Context, input *struct{}) (*GetStatsResponse, error) {
+	stats, err := h.statsUsecase.GetStats(ctx)
+	if err != nil {
+		return nil, ErrInternalServerError(err)
+	}
+
+	return &GetStatsResponse{Body: stats}, nil
+}
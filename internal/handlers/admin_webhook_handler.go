@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/middlewares"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
+)
+
+var (
+	ErrWebhookEndpointNotFound = huma.Error404NotFound("webhook endpoint not found")
+	ErrWebhookDeliveryNotFound = huma.Error404NotFound("webhook delivery not found")
+	ErrInvalidWebhookURL       = huma.Error400BadRequest("webhook url must be an absolute http or https url")
+	ErrInvalidEventType        = huma.Error400BadRequest("invalid event type")
+)
+
+type adminWebhookHandler struct {
+	webhookUsecase usecases.WebhookUsecase
+	mid            middlewares.Middleware
+}
+
+func InitAdminWebhookHandler(adminGroup huma.API, webhookUsecase usecases.WebhookUsecase, mid middlewares.Middleware) {
+	handler := &adminWebhookHandler{
+		webhookUsecase: webhookUsecase,
+		mid:            mid,
+	}
+	adminWebhookTag := "admin-webhook"
+
+	huma.Get(adminGroup, "/webhooks", handler.ListWebhooks, func(o *huma.Operation) {
+		o.Summary = "List webhook endpoints"
+		o.Description = "Retrieve every webhook endpoint, signing secrets are not included. Requires `admin` role."
+		o.Tags = []string{adminWebhookTag}
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Post(adminGroup, "/webhooks", handler.CreateWebhook, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(createWebhookErrorList)
+		o.Summary = "Create a webhook endpoint"
+		o.Description = "Register a partner endpoint that receives the selected event types. The signing secret is only returned by this operation. Requires `admin` role." + errDoc
+		o.DefaultStatus = 201
+		o.Tags = []string{adminWebhookTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Patch(adminGroup, "/webhooks/{id}", handler.UpdateWebhook, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(updateWebhookErrorList)
+		o.Summary = "Update a webhook endpoint"
+		o.Description = "Partially update a webhook endpoint, only provided fields are changed. Deliveries already queued are sent to the new url. Requires `admin` role." + errDoc
+		o.Tags = []string{adminWebhookTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Delete(adminGroup, "/webhooks/{id}", handler.DeleteWebhook, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(deleteWebhookErrorList)
+		o.Summary = "Delete a webhook endpoint"
+		o.Description = "Delete a webhook endpoint together with its delivery log. Requires `admin` role." + errDoc
+		o.DefaultStatus = 204
+		o.Tags = []string{adminWebhookTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Get(adminGroup, "/webhooks/{id}/deliveries", handler.ListWebhookDeliveries, func(o *huma.Operation) {
+		o.Summary = "List webhook deliveries"
+		o.Description = "Retrieve the latest deliveries of a webhook endpoint, newest first. Requires `admin` role."
+		o.Tags = []string{adminWebhookTag}
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Post(adminGroup, "/webhooks/deliveries/{id}/replay", handler.ReplayWebhookDelivery, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(replayWebhookDeliveryErrorList)
+		o.Summary = "Replay a webhook delivery"
+		o.Description = "Send a delivery again with its original body, whatever its status. The attempt count starts over. Requires `admin` role." + errDoc
+		o.DefaultStatus = 202
+		o.Tags = []string{adminWebhookTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+}
+
+var (
+	createWebhookErrorList         = []huma.StatusError{ErrInvalidWebhookURL, ErrInvalidEventType, ErrInternalServerError()}
+	updateWebhookErrorList         = []huma.StatusError{ErrWebhookEndpointNotFound, ErrNothingToUpdate, ErrInvalidWebhookURL, ErrInvalidEventType, ErrInternalServerError()}
+	deleteWebhookErrorList         = []huma.StatusError{ErrWebhookEndpointNotFound, ErrInternalServerError()}
+	replayWebhookDeliveryErrorList = []huma.StatusError{ErrWebhookDeliveryNotFound, ErrInternalServerError()}
+)
+
+type AdminWebhookItem struct {
+	ID          int64              `json:"id"`
+	URL         string             `json:"url"`
+	EventTypes  []models.EventType `json:"event_types"`
+	Description string             `json:"description"`
+	IsActive    bool               `json:"is_active"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+func toAdminWebhookItem(e *models.WebhookEndpoint) AdminWebhookItem {
+	eventTypes := make([]models.EventType, 0, len(e.EventTypes))
+	for _, t := range e.EventTypes {
+		eventTypes = append(eventTypes, models.EventType(t))
+	}
+
+	return AdminWebhookItem{
+		ID:          e.ID,
+		URL:         e.URL,
+		EventTypes:  eventTypes,
+		Description: e.Description,
+		IsActive:    e.IsActive,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+type AdminListWebhooksResponse struct {
+	Body struct {
+		Webhooks []AdminWebhookItem `json:"webhooks"`
+	}
+}
+
+func (h *adminWebhookHandler) ListWebhooks(ctx context.Context, input *struct{}) (*AdminListWebhooksResponse, error) {
+	endpoints, err := h.webhookUsecase.ListEndpoints(ctx)
+	if err != nil {
+		return nil, ErrInternalServerError(err)
+	}
+
+	resp := &AdminListWebhooksResponse{}
+	resp.Body.Webhooks = make([]AdminWebhookItem, 0, len(endpoints))
+	for i := range endpoints {
+		resp.Body.Webhooks = append(resp.Body.Webhooks, toAdminWebhookItem(&endpoints[i]))
+	}
+	return resp, nil
+}
+
+type CreateWebhookRequest struct {
+	Body struct {
+		URL         string             `json:"url"         format:"uri"`
+		EventTypes  []models.EventType `json:"event_types" minItems:"1" enum:"booking.created,booking.cancelled,workshop.attended,booth.checked_in,stamps.redeemed,user.registered"`
+		Description string             `json:"description" required:"false"`
+	}
+}
+
+type CreateWebhookResponse struct {
+	Body struct {
+		AdminWebhookItem
+		Secret string `json:"secret" doc:"Key of the HMAC-SHA256 signature in X-Webhook-Signature, shown only once"`
+	}
+}
+
+func (h *adminWebhookHandler) CreateWebhook(ctx context.Context, input *CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	endpoint := &models.WebhookEndpoint{
+		URL:         input.Body.URL,
+		EventTypes:  make([]string, 0, len(input.Body.EventTypes)),
+		Description: input.Body.Description,
+	}
+	for _, t := range input.Body.EventTypes {
+		endpoint.EventTypes = append(endpoint.EventTypes, string(t))
+	}
+
+	if err := h.webhookUsecase.CreateEndpoint(ctx, endpoint); err != nil {
+		return nil, mapWebhookWriteErr(err)
+	}
+
+	resp := &CreateWebhookResponse{}
+	resp.Body.AdminWebhookItem = toAdminWebhookItem(endpoint)
+	resp.Body.Secret = endpoint.Secret
+	return resp, nil
+}
+
+type UpdateWebhookRequest struct {
+	ID   int64 `path:"id"`
+	Body struct {
+		URL         *string            `json:"url,omitempty"         format:"uri"`
+		EventTypes  []models.EventType `json:"event_types,omitempty" minItems:"1" enum:"booking.created,booking.cancelled,workshop.attended,booth.checked_in,stamps.redeemed,user.registered"`
+		Description *string            `json:"description,omitempty"`
+		IsActive    *bool              `json:"is_active,omitempty"   doc:"Inactive endpoints receive no new deliveries"`
+	}
+}
+
+type UpdateWebhookResponse struct {
+	Body AdminWebhookItem
+}
+
+func (h *adminWebhookHandler) UpdateWebhook(ctx context.Context, input *UpdateWebhookRequest) (*UpdateWebhookResponse, error) {
+	endpoint, err := h.webhookUsecase.UpdateEndpoint(ctx, input.ID, &models.WebhookEndpointOptional{
+		URL:         input.Body.URL,
+		EventTypes:  input.Body.EventTypes,
+		Description: input.Body.Description,
+		IsActive:    input.Body.IsActive,
+	})
+	if err != nil {
+		return nil, mapWebhookWriteErr(err)
+	}
+
+	return &UpdateWebhookResponse{
+		Body: toAdminWebhookItem(endpoint),
+	}, nil
+}
+
+type DeleteWebhookRequest struct {
+	ID int64 `path:"id"`
+}
+
+type DeleteWebhookResponse struct {
+	Body *struct{}
+}
+
+func (h *adminWebhookHandler) DeleteWebhook(ctx context.Context, input *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	if err := h.webhookUsecase.DeleteEndpoint(ctx, input.ID); err != nil {
+		return nil, mapWebhookWriteErr(err)
+	}
+
+	return &DeleteWebhookResponse{}, nil
+}
+
+type ListWebhookDeliveriesRequest struct {
+	ID     int64                        `path:"id"`
+	Status models.WebhookDeliveryStatus `query:"status" enum:"pending,succeeded,failed" required:"false"`
+	Limit  int                          `query:"limit"  default:"50" minimum:"1" maximum:"500"`
+}
+
+type AdminWebhookDeliveryItem struct {
+	ID             int64                        `json:"id"`
+	EventID        int64                        `json:"event_id"`
+	EventType      models.EventType             `json:"event_type"`
+	Payload        json.RawMessage              `json:"payload"`
+	Status         models.WebhookDeliveryStatus `json:"status"`
+	Attempts       int                          `json:"attempts"`
+	NextAttemptAt  *time.Time                   `json:"next_attempt_at,omitempty"  doc:"Only set while the delivery is pending"`
+	LastStatusCode *int                         `json:"last_status_code,omitempty"`
+	LastError      *string                      `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time                   `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time                    `json:"created_at"`
+}
+
+type ListWebhookDeliveriesResponse struct {
+	Body struct {
+		Deliveries []AdminWebhookDeliveryItem `json:"deliveries"`
+	}
+}
+
+func (h *adminWebhookHandler) ListWebhookDeliveries(ctx context.Context, input *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	deliveries, err := h.webhookUsecase.ListDeliveries(ctx, input.ID, input.Status, input.Limit)
+	if err != nil {
+		return nil, ErrInternalServerError(err)
+	}
+
+	resp := &ListWebhookDeliveriesResponse{}
+	resp.Body.Deliveries = make([]AdminWebhookDeliveryItem, 0, len(deliveries))
+	for _, d := range deliveries {
+		item := AdminWebhookDeliveryItem{
+			ID:             d.ID,
+			EventID:        d.EventID,
+			EventType:      d.EventType,
+			Payload:        d.Payload,
+			Status:         d.Status,
+			Attempts:       d.Attempts,
+			LastStatusCode: d.LastStatusCode,
+			LastError:      d.LastError,
+			DeliveredAt:    d.DeliveredAt,
+			CreatedAt:      d.CreatedAt,
+		}
+		if d.Status == models.WebhookDeliveryPending {
+			item.NextAttemptAt = &d.NextAttemptAt
+		}
+		resp.Body.Deliveries = append(resp.Body.Deliveries, item)
+	}
+	return resp, nil
+}
+
+type ReplayWebhookDeliveryRequest struct {
+	ID int64 `path:"id"`
+}
+
+type ReplayWebhookDeliveryResponse struct {
+	Body *struct{}
+}
+
+func (h *adminWebhookHandler) ReplayWebhookDelivery(ctx context.Context, input *ReplayWebhookDeliveryRequest) (*ReplayWebhookDeliveryResponse, error) {
+	if err := h.webhookUsecase.ReplayDelivery(ctx, input.ID); err != nil {
+		switch err {
+		case repositories.ErrWebhookDeliveryNotFound:
+			return nil, ErrWebhookDeliveryNotFound
+		default:
+			return nil, ErrInternalServerError(err)
+		}
+	}
+
+	return &ReplayWebhookDeliveryResponse{}, nil
+}
+
+func mapWebhookWriteErr(err error) huma.StatusError {
+	switch err {
+	case repositories.ErrWebhookEndpointNotFound:
+		return ErrWebhookEndpointNotFound
+	case usecases.ErrInvalidWebhookURL:
+		return ErrInvalidWebhookURL
+	case usecases.ErrInvalidEventType:
+		return ErrInvalidEventType
+	case usecases.ErrNothingToUpdate:
+		return ErrNothingToUpdate
+	default:
+		return ErrInternalServerError(err)
+	}
+}
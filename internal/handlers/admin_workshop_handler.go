@@ -0,0 +1,570 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/middlewares"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/myValidator"
+)
+
+var (
+	ErrInvalidTimeOfDay            = huma.Error400BadRequest("invalid time format, expected HH:MM")
+	ErrInvalidWorkshopTime         = huma.Error400BadRequest("end time must be after start time")
+	ErrInvalidTotalSeats           = huma.Error400BadRequest("total seats must be at least 1")
+	ErrTotalSeatsBelowRegistered   = huma.Error400BadRequest("total seats cannot be less than registered count")
+	ErrWorkshopHasActiveBookings   = huma.Error409Conflict("workshop still has active bookings")
+	ErrNothingToUpdate             = huma.Error400BadRequest("nothing to update")
+	ErrWorkshopConstraintViolation = huma.Error400BadRequest("workshop violates a database constraint (e.g. event date outside the open house days)")
+	ErrInvalidBookingWindow        = huma.Error400BadRequest("booking must close after it opens")
+	ErrInvalidSeatReleases         = huma.Error400BadRequest("seat releases must have distinct times, increasing percents and end at 100 percent")
+	ErrInvalidParticipantRules     = huma.Error400BadRequest("participant rules must have distinct types and reserve at most 100 percent in total")
+	ErrCustomParticipantRules      = huma.Error409Conflict("workshop has custom participant rules, set them again to change its category")
+)
+
+type adminWorkshopHandler struct {
+	workshopUsecase usecases.WorkshopUsecase
+	mid             middlewares.Middleware
+}
+
+func InitAdminWorkshopHandler(adminGroup huma.API, workshopUsecase usecases.WorkshopUsecase, mid middlewares.Middleware) {
+	handler := &adminWorkshopHandler{
+		workshopUsecase: workshopUsecase,
+		mid:             mid,
+	}
+	adminWorkshopTag := "admin-workshop"
+
+	huma.Get(adminGroup, "/workshops", handler.ListWorkshops, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(adminListWorkshopsErrorList)
+		o.Summary = "List workshops with check-in codes"
+		o.Description = "Retrieve every workshop. The check-in code is only included for admins and the workshops the host is assigned to. Requires `workshop_host` role." + errDoc
+		o.Tags = []string{adminWorkshopTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleWorkshopHost)}
+	})
+
+	huma.Post(adminGroup, "/workshops", handler.CreateWorkshop, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(createWorkshopErrorList)
+		o.Summary = "Create a workshop"
+		o.Description = "Create a workshop. A check-in code is generated automatically. Requires `admin` role." + errDoc
+		o.DefaultStatus = 201
+		o.Tags = []string{adminWorkshopTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Patch(adminGroup, "/workshops/{id}", handler.UpdateWorkshop, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(updateWorkshopErrorList)
+		o.Summary = "Update a workshop"
+		o.Description = "Partially update a workshop, only provided fields are changed. Changing the category moves the workshop to the default participant rules of the new category, " +
+			"it is refused while the rules differ from the defaults of the old one. Requires `admin` role." + errDoc
+		o.Tags = []string{adminWorkshopTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Delete(adminGroup, "/workshops/{id}", handler.DeleteWorkshop, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(deleteWorkshopErrorList)
+		o.Summary = "Delete a workshop"
+		o.Description = "Delete a workshop that has no active bookings. Requires `admin` role." + errDoc
+		o.DefaultStatus = 204
+		o.Tags = []string{adminWorkshopTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Post(adminGroup, "/workshops/{id}/rotate-code", handler.RotateCheckInCode, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(rotateCheckInCodeErrorList)
+		o.Summary = "Rotate a workshop check-in code"
+		o.Description = "Generate a new check-in code for a workshop, invalidating the previous QR code immediately. Requires `admin` role or a `workshop_host` assigned to the workshop." + errDoc
+		o.Tags = []string{adminWorkshopTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleWorkshopHost)}
+	})
+
+	huma.Get(adminGroup, "/workshops/{id}/seat-releases", handler.GetSeatReleases, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(getSeatReleasesErrorList)
+		o.Summary = "Get the seat release schedule of a workshop"
+		o.Description = "Retrieve the staged seat releases of a workshop ordered by time. Requires `workshop_host` role." + errDoc
+		o.Tags = []string{adminWorkshopTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleWorkshopHost)}
+	})
+
+	huma.Put(adminGroup, "/workshops/{id}/seat-releases", handler.SetSeatReleases, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(setSeatReleasesErrorList)
+		o.Summary = "Replace the seat release schedule of a workshop"
+		o.Description = "Open the seats of a workshop in stages, each release opens a cumulative percent of the total seats and the last one must reach 100. An empty list opens every seat at once. Seats already booked are kept. Requires `admin` role." + errDoc
+		o.Tags = []string{adminWorkshopTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Get(adminGroup, "/workshops/{id}/participant-rules", handler.GetParticipantRules, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(getParticipantRulesErrorList)
+		o.Summary = "Get the participant rules of a workshop"
+		o.Description = "Retrieve the participant types allowed to book a workshop and their reserved seat quotas. Requires `workshop_host` role." + errDoc
+		o.Tags = []string{adminWorkshopTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleWorkshopHost)}
+	})
+
+	huma.Put(adminGroup, "/workshops/{id}/participant-rules", handler.SetParticipantRules, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(setParticipantRulesErrorList)
+		o.Summary = "Replace the participant rules of a workshop"
+		o.Description = "Set which participant types may book a workshop. A rule may reserve a percent of the total seats for its type, other types cannot take reserved seats until that type has filled them. An empty list admits every participant type. Existing bookings are kept. Requires `admin` role." + errDoc
+		o.Tags = []string{adminWorkshopTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+}
+
+var (
+	adminListWorkshopsErrorList = []huma.StatusError{ErrEmailNotFound, ErrInternalServerError()}
+
+	createWorkshopErrorList    = []huma.StatusError{ErrInvalidCategory, ErrInvalidEventDate, ErrInvalidTimeOfDay, ErrInvalidWorkshopTime, ErrInvalidTotalSeats, ErrInvalidBookingWindow, ErrWorkshopConstraintViolation, ErrInternalServerError()}
+	updateWorkshopErrorList    = []huma.StatusError{ErrWorkshopNotFound, ErrNothingToUpdate, ErrInvalidCategory, ErrInvalidEventDate, ErrInvalidTimeOfDay, ErrInvalidWorkshopTime, ErrInvalidTotalSeats, ErrTotalSeatsBelowRegistered, ErrInvalidBookingWindow, ErrWorkshopConstraintViolation, ErrCustomParticipantRules, ErrInternalServerError()}
+	deleteWorkshopErrorList    = []huma.StatusError{ErrWorkshopNotFound, ErrWorkshopHasActiveBookings, ErrInternalServerError()}
+	rotateCheckInCodeErrorList = []huma.StatusError{ErrEmailNotFound, ErrStaffNotAssigned, ErrWorkshopNotFound, ErrInternalServerError()}
+	getSeatReleasesErrorList   = []huma.StatusError{ErrWorkshopNotFound, ErrInternalServerError()}
+	setSeatReleasesErrorList   = []huma.StatusError{ErrWorkshopNotFound, ErrInvalidSeatReleases, ErrInternalServerError()}
+
+	getParticipantRulesErrorList = []huma.StatusError{ErrWorkshopNotFound, ErrInternalServerError()}
+	setParticipantRulesErrorList = []huma.StatusError{ErrWorkshopNotFound, ErrInvalidParticipantType, ErrInvalidParticipantRules, ErrInternalServerError()}
+)
+
+type AdminWorkshopItem struct {
+	WorkshopItem
+	CheckInCode string `json:"check_in_code,omitempty" doc:"Code to encode in the workshop QR, formatted as W-<uuid>. Omitted for workshops the host is not assigned to"`
+}
+
+func toAdminWorkshopItem(w *models.Workshop) AdminWorkshopItem {
+	item := AdminWorkshopItem{
+		WorkshopItem: WorkshopItem{
+			ID:                w.ID,
+			Name:              w.Name,
+			Description:       w.Description,
+			Category:          w.Category,
+			Affiliation:       w.Affiliation,
+			EventDate:         w.EventDate,
+			StartTime:         w.StartTime,
+			EndTime:           w.EndTime,
+			Location:          w.Location,
+			TotalSeats:        w.TotalSeats,
+			RegisteredCount:   w.RegisteredCount,
+			Image:             w.Image,
+			BookingOpensAt:    w.BookingOpensAt,
+			BookingClosesAt:   w.BookingClosesAt,
+			AvailableSeatsNow: w.AvailableSeatsNow,
+		},
+	}
+	if w.CheckInCode != "" {
+		item.CheckInCode = usecases.PrefixWorkshop + w.CheckInCode
+	}
+	return item
+}
+
+type AdminListWorkshopsRequest struct{}
+
+type AdminListWorkshopsResponse struct {
+	Body AdminListWorkshopsResponseBody
+}
+
+type AdminListWorkshopsResponseBody struct {
+	Workshops []AdminWorkshopItem `json:"workshops"`
+}
+
+func (h *adminWorkshopHandler) ListWorkshops(ctx context.Context, input *AdminListWorkshopsRequest) (*AdminListWorkshopsResponse, error) {
+	staff, err := staffMemberFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workshops, err := h.workshopUsecase.ListStaffWorkshops(ctx, staff)
+	if err != nil {
+		return nil, ErrInternalServerError(err)
+	}
+
+	items := make([]AdminWorkshopItem, 0, len(workshops))
+	for _, w := range workshops {
+		items = append(items, toAdminWorkshopItem(w))
+	}
+
+	return &AdminListWorkshopsResponse{
+		Body: AdminListWorkshopsResponseBody{
+			Workshops: items,
+		},
+	}, nil
+}
+
+type CreateWorkshopRequest struct {
+	Body struct {
+		Name        string                  `json:"name"        minLength:"1"`
+		Description string                  `json:"description"`
+		Category    models.WorkShopCategory `json:"category"    enum:"department,club"`
+		Affiliation string                  `json:"affiliation" minLength:"1"`
+		EventDate   string                  `json:"event_date"  doc:"date in format 2026-03-28"`
+		StartTime   string                  `json:"start_time"  doc:"time in format 13:00"`
+		EndTime     string                  `json:"end_time"    doc:"time in format 15:30"`
+		Location    string                  `json:"location"`
+		TotalSeats  int                     `json:"total_seats" minimum:"1"`
+		Image       string                  `json:"image"       required:"false"`
+
+		BookingOpensAt  *time.Time `json:"booking_opens_at,omitempty"  doc:"Booking is open right away when omitted"`
+		BookingClosesAt *time.Time `json:"booking_closes_at,omitempty" doc:"Booking never closes when omitted"`
+	}
+}
+
+type CreateWorkshopResponse struct {
+	Body AdminWorkshopItem
+}
+
+func (h *adminWorkshopHandler) CreateWorkshop(ctx context.Context, input *CreateWorkshopRequest) (*CreateWorkshopResponse, error) {
+	startTime, err := myValidator.ParseTimeOfDay(input.Body.StartTime)
+	if err != nil {
+		return nil, ErrInvalidTimeOfDay
+	}
+	endTime, err := myValidator.ParseTimeOfDay(input.Body.EndTime)
+	if err != nil {
+		return nil, ErrInvalidTimeOfDay
+	}
+
+	workshop := &models.Workshop{
+		Name:        input.Body.Name,
+		Description: input.Body.Description,
+		Category:    input.Body.Category,
+		Affiliation: input.Body.Affiliation,
+		EventDate:   input.Body.EventDate,
+		StartTime:   startTime,
+		EndTime:     endTime,
+		Location:    input.Body.Location,
+		TotalSeats:  input.Body.TotalSeats,
+		Image:       input.Body.Image,
+
+		BookingOpensAt:  input.Body.BookingOpensAt,
+		BookingClosesAt: input.Body.BookingClosesAt,
+	}
+
+	if err := h.workshopUsecase.CreateWorkshop(ctx, workshop); err != nil {
+		return nil, mapWorkshopWriteErr(err)
+	}
+
+	return &CreateWorkshopResponse{
+		Body: toAdminWorkshopItem(workshop),
+	}, nil
+}
+
+type UpdateWorkshopRequest struct {
+	ID   int64 `path:"id"`
+	Body struct {
+		Name        *string                  `json:"name,omitempty"        minLength:"1"`
+		Description *string                  `json:"description,omitempty"`
+		Category    *models.WorkShopCategory `json:"category,omitempty"    enum:"department,club"`
+		Affiliation *string                  `json:"affiliation,omitempty" minLength:"1"`
+		EventDate   *string                  `json:"event_date,omitempty"  doc:"date in format 2026-03-28"`
+		StartTime   *string                  `json:"start_time,omitempty"  doc:"time in format 13:00"`
+		EndTime     *string                  `json:"end_time,omitempty"    doc:"time in format 15:30"`
+		Location    *string                  `json:"location,omitempty"`
+		TotalSeats  *int                     `json:"total_seats,omitempty" minimum:"1"`
+		Image       *string                  `json:"image,omitempty"`
+
+		BookingOpensAt  NullableTime `json:"booking_opens_at,omitempty"  doc:"null opens booking right away"`
+		BookingClosesAt NullableTime `json:"booking_closes_at,omitempty" doc:"null keeps booking open until the workshop ends"`
+	}
+}
+
+// NullableTime tells an omitted field, which keeps the stored value, from an explicit null, which clears it.
+type NullableTime struct {
+	Set   bool
+	Value *time.Time
+}
+
+func (t *NullableTime) UnmarshalJSON(data []byte) error {
+	t.Set = true
+	if string(data) == "null" {
+		t.Value = nil
+		return nil
+	}
+	var value time.Time
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	t.Value = &value
+	return nil
+}
+
+func (NullableTime) Schema(r huma.Registry) *huma.Schema {
+	return &huma.Schema{Type: huma.TypeString, Format: "date-time", Nullable: true}
+}
+
+type UpdateWorkshopResponse struct {
+	Body AdminWorkshopItem
+}
+
+func (h *adminWorkshopHandler) UpdateWorkshop(ctx context.Context, input *UpdateWorkshopRequest) (*UpdateWorkshopResponse, error) {
+	update := &models.WorkshopOptional{
+		Name:        input.Body.Name,
+		Description: input.Body.Description,
+		Category:    input.Body.Category,
+		Affiliation: input.Body.Affiliation,
+		EventDate:   input.Body.EventDate,
+		Location:    input.Body.Location,
+		TotalSeats:  input.Body.TotalSeats,
+		Image:       input.Body.Image,
+
+		BookingOpensAt:       input.Body.BookingOpensAt.Value,
+		ClearBookingOpensAt:  input.Body.BookingOpensAt.Set && input.Body.BookingOpensAt.Value == nil,
+		BookingClosesAt:      input.Body.BookingClosesAt.Value,
+		ClearBookingClosesAt: input.Body.BookingClosesAt.Set && input.Body.BookingClosesAt.Value == nil,
+	}
+	if input.Body.StartTime != nil {
+		startTime, err := myValidator.ParseTimeOfDay(*input.Body.StartTime)
+		if err != nil {
+			return nil, ErrInvalidTimeOfDay
+		}
+		update.StartTime = &startTime
+	}
+	if input.Body.EndTime != nil {
+		endTime, err := myValidator.ParseTimeOfDay(*input.Body.EndTime)
+		if err != nil {
+			return nil, ErrInvalidTimeOfDay
+		}
+		update.EndTime = &endTime
+	}
+
+	workshop, err := h.workshopUsecase.UpdateWorkshop(ctx, input.ID, update)
+	if err != nil {
+		return nil, mapWorkshopWriteErr(err)
+	}
+
+	return &UpdateWorkshopResponse{
+		Body: toAdminWorkshopItem(workshop),
+	}, nil
+}
+
+type DeleteWorkshopRequest struct {
+	ID int64 `path:"id"`
+}
+
+type DeleteWorkshopResponse struct {
+	Body *struct{}
+}
+
+func (h *adminWorkshopHandler) DeleteWorkshop(ctx context.Context, input *DeleteWorkshopRequest) (*DeleteWorkshopResponse, error) {
+	err := h.workshopUsecase.DeleteWorkshop(ctx, input.ID)
+	if err != nil {
+		switch err {
+		case repositories.ErrWorkshopNotFound:
+			return nil, ErrWorkshopNotFound
+		case usecases.ErrWorkshopHasActiveBookings:
+			return nil, ErrWorkshopHasActiveBookings
+		default:
+			return nil, ErrInternalServerError(err)
+		}
+	}
+
+	return &DeleteWorkshopResponse{}, nil
+}
+
+type RotateCheckInCodeRequest struct {
+	ID int64 `path:"id"`
+}
+
+type RotateCheckInCodeResponse struct {
+	Body RotateCheckInCodeResponseBody
+}
+
+type RotateCheckInCodeResponseBody struct {
+	CheckInCode string    `json:"check_in_code" doc:"New code to encode in the workshop QR, formatted as W-<uuid>"`
+	RotatedAt   time.Time `json:"rotated_at"`
+}
+
+func (h *adminWorkshopHandler) RotateCheckInCode(ctx context.Context, input *RotateCheckInCodeRequest) (*RotateCheckInCodeResponse, error) {
+	staff, err := staffMemberFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	code, err := h.workshopUsecase.RotateCheckInCode(ctx, staff, input.ID)
+	if err != nil {
+		switch err {
+		case usecases.ErrStaffNotAssigned:
+			return nil, ErrStaffNotAssigned
+		case repositories.ErrWorkshopNotFound:
+			return nil, ErrWorkshopNotFound
+		default:
+			return nil, ErrInternalServerError(err)
+		}
+	}
+
+	return &RotateCheckInCodeResponse{
+		Body: RotateCheckInCodeResponseBody{
+			CheckInCode: usecases.PrefixWorkshop + code,
+			RotatedAt:   time.Now(),
+		},
+	}, nil
+}
+
+type SeatReleaseItem struct {
+	ReleaseAt time.Time `json:"release_at"`
+	Percent   int       `json:"percent"    minimum:"1" maximum:"100" doc:"Cumulative percent of the total seats bookable from release_at"`
+}
+
+type GetSeatReleasesRequest struct {
+	ID int64 `path:"id"`
+}
+
+type SeatReleasesResponse struct {
+	Body SeatReleasesBody
+}
+
+type SeatReleasesBody struct {
+	Releases []SeatReleaseItem `json:"releases"`
+}
+
+func toSeatReleasesResponse(releases []models.SeatRelease) *SeatReleasesResponse {
+	items := make([]SeatReleaseItem, 0, len(releases))
+	for _, r := range releases {
+		items = append(items, SeatReleaseItem{
+			ReleaseAt: r.ReleaseAt,
+			Percent:   r.Percent,
+		})
+	}
+
+	return &SeatReleasesResponse{
+		Body: SeatReleasesBody{
+			Releases: items,
+		},
+	}
+}
+
+func (h *adminWorkshopHandler) GetSeatReleases(ctx context.Context, input *GetSeatReleasesRequest) (*SeatReleasesResponse, error) {
+	releases, err := h.workshopUsecase.GetSeatReleases(ctx, input.ID)
+	if err != nil {
+		return nil, mapWorkshopWriteErr(err)
+	}
+
+	return toSeatReleasesResponse(releases), nil
+}
+
+type SetSeatReleasesRequest struct {
+	ID   int64 `path:"id"`
+	Body SeatReleasesBody
+}
+
+func (h *adminWorkshopHandler) SetSeatReleases(ctx context.Context, input *SetSeatReleasesRequest) (*SeatReleasesResponse, error) {
+	releases := make([]models.SeatRelease, 0, len(input.Body.Releases))
+	for _, r := range input.Body.Releases {
+		releases = append(releases, models.SeatRelease{
+			ReleaseAt: r.ReleaseAt,
+			Percent:   r.Percent,
+		})
+	}
+
+	releases, err := h.workshopUsecase.SetSeatReleases(ctx, input.ID, releases)
+	if err != nil {
+		return nil, mapWorkshopWriteErr(err)
+	}
+
+	return toSeatReleasesResponse(releases), nil
+}
+
+type ParticipantRuleItem struct {
+	ParticipantType models.ParticipantType `json:"participant_type"           enum:"student,intania,outside_student,alumni,teacher,other"`
+	ReservedPercent *int                   `json:"reserved_percent,omitempty" required:"false" minimum:"1" maximum:"100" doc:"Percent of the total seats only this participant type may take"`
+}
+
+type GetParticipantRulesRequest struct {
+	ID int64 `path:"id"`
+}
+
+type ParticipantRulesResponse struct {
+	Body ParticipantRulesBody
+}
+
+type ParticipantRulesBody struct {
+	Rules []ParticipantRuleItem `json:"rules"`
+}
+
+func toParticipantRulesResponse(rules []models.ParticipantRule) *ParticipantRulesResponse {
+	items := make([]ParticipantRuleItem, 0, len(rules))
+	for _, r := range rules {
+		items = append(items, ParticipantRuleItem{
+			ParticipantType: r.ParticipantType,
+			ReservedPercent: r.ReservedPercent,
+		})
+	}
+
+	return &ParticipantRulesResponse{
+		Body: ParticipantRulesBody{
+			Rules: items,
+		},
+	}
+}
+
+func (h *adminWorkshopHandler) GetParticipantRules(ctx context.Context, input *GetParticipantRulesRequest) (*ParticipantRulesResponse, error) {
+	rules, err := h.workshopUsecase.GetParticipantRules(ctx, input.ID)
+	if err != nil {
+		return nil, mapWorkshopWriteErr(err)
+	}
+
+	return toParticipantRulesResponse(rules), nil
+}
+
+type SetParticipantRulesRequest struct {
+	ID   int64 `path:"id"`
+	Body ParticipantRulesBody
+}
+
+func (h *adminWorkshopHandler) SetParticipantRules(ctx context.Context, input *SetParticipantRulesRequest) (*ParticipantRulesResponse, error) {
+	rules := make([]models.ParticipantRule, 0, len(input.Body.Rules))
+	for _, r := range input.Body.Rules {
+		rules = append(rules, models.ParticipantRule{
+			ParticipantType: r.ParticipantType,
+			ReservedPercent: r.ReservedPercent,
+		})
+	}
+
+	rules, err := h.workshopUsecase.SetParticipantRules(ctx, input.ID, rules)
+	if err != nil {
+		return nil, mapWorkshopWriteErr(err)
+	}
+
+	return toParticipantRulesResponse(rules), nil
+}
+
+func mapWorkshopWriteErr(err error) error {
+	switch err {
+	case repositories.ErrWorkshopNotFound:
+		return ErrWorkshopNotFound
+	case repositories.ErrWorkshopConstraintViolation:
+		return ErrWorkshopConstraintViolation
+	case myValidator.ErrInvalidWorkshopCategory:
+		return ErrInvalidCategory
+	case myValidator.ErrInvalidEventDate:
+		return ErrInvalidEventDate
+	case usecases.ErrInvalidWorkshopTime:
+		return ErrInvalidWorkshopTime
+	case usecases.ErrInvalidTotalSeats:
+		return ErrInvalidTotalSeats
+	case usecases.ErrTotalSeatsBelowRegistered:
+		return ErrTotalSeatsBelowRegistered
+	case usecases.ErrNothingToUpdate:
+		return ErrNothingToUpdate
+	case usecases.ErrInvalidBookingWindow:
+		return ErrInvalidBookingWindow
+	case usecases.ErrInvalidSeatReleases:
+		return ErrInvalidSeatReleases
+	case myValidator.ErrInvalidParticipantType:
+		return ErrInvalidParticipantType
+	case usecases.ErrInvalidParticipantRules:
+		return ErrInvalidParticipantRules
+	case usecases.ErrCustomParticipantRules:
+		return ErrCustomParticipantRules
+	default:
+		return ErrInternalServerError(err)
+	}
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/middlewares"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/ical"
+)
+
+type agendaHandler struct {
+	agendaUsecase usecases.AgendaUsecase
+	userUsecase   usecases.UserUsecase
+	mid           middlewares.Middleware
+}
+
+func InitAgendaHandler(
+	activityGroup huma.API,
+	userGroup huma.API,
+	agendaUsecase usecases.AgendaUsecase,
+	userUsecase usecases.UserUsecase,
+	mid middlewares.Middleware,
+) {
+	handler := &agendaHandler{
+		agendaUsecase: agendaUsecase,
+		userUsecase:   userUsecase,
+		mid:           mid,
+	}
+	agendaTag := "agenda"
+
+	huma.Put(activityGroup, "/{id}/save", handler.SaveActivity, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(saveActivityErrorList)
+		o.Summary = "Star an activity"
+		o.Description = "Add an activity to the agenda of the current user. Starring an activity twice has no effect." + errDoc
+		o.DefaultStatus = 204
+		o.Tags = []string{agendaTag}
+		o.Errors = errCodes
+	})
+
+	huma.Delete(activityGroup, "/{id}/save", handler.UnsaveActivity, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(unsaveActivityErrorList)
+		o.Summary = "Unstar an activity"
+		o.Description = "Remove an activity from the agenda of the current user." + errDoc
+		o.DefaultStatus = 204
+		o.Tags = []string{agendaTag}
+		o.Errors = errCodes
+	})
+
+	huma.Get(userGroup, "/me/agenda", handler.GetMyAgenda, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(getMyAgendaErrorList)
+		o.Summary = "Get my agenda"
+		o.Description = "Retrieve the booked workshops and starred activities of the current user sorted by date and start time. Items that overlap another item are flagged with `has_conflict`." + errDoc
+		o.Tags = []string{agendaTag}
+		o.Errors = errCodes
+	})
+
+	huma.Get(userGroup, "/me/agenda.ics", handler.GetMyAgendaICS, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(getMyAgendaErrorList)
+		o.Summary = "Export my agenda as iCalendar"
+		o.Description = "Download the agenda of the current user as an RFC 5545 calendar with times in Asia/Bangkok." + errDoc
+		o.Tags = []string{agendaTag}
+		o.Errors = errCodes
+	})
+}
+
+var (
+	saveActivityErrorList   = []huma.StatusError{ErrEmailNotFound, ErrUserNotFound, ErrActivityNotFound, ErrInternalServerError()}
+	unsaveActivityErrorList = []huma.StatusError{ErrEmailNotFound, ErrUserNotFound, ErrInternalServerError()}
+	getMyAgendaErrorList    = []huma.StatusError{ErrEmailNotFound, ErrUserNotFound, ErrInternalServerError()}
+)
+
+type SaveActivityRequest struct {
+	ID int64 `path:"id" doc:"Activity ID"`
+}
+
+type SaveActivityResponse struct {
+	Body *struct{}
+}
+
+func (h *agendaHandler) SaveActivity(ctx context.Context, input *SaveActivityRequest) (*SaveActivityResponse, error) {
+	userID, err := h.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.agendaUsecase.SaveActivity(ctx, userID, input.ID)
+	if err != nil {
+		switch err {
+		case repositories.ErrActivityNotFound:
+			return nil, ErrActivityNotFound
+		default:
+			return nil, ErrInternalServerError(err)
+		}
+	}
+
+	return &SaveActivityResponse{}, nil
+}
+
+func (h *agendaHandler) UnsaveActivity(ctx context.Context, input *SaveActivityRequest) (*SaveActivityResponse, error) {
+	userID, err := h.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.agendaUsecase.UnsaveActivity(ctx, userID, input.ID); err != nil {
+		return nil, ErrInternalServerError(err)
+	}
+
+	return &SaveActivityResponse{}, nil
+}
+
+type GetMyAgendaRequest struct{}
+
+type GetMyAgendaResponse struct {
+	Body GetMyAgendaResponseBody
+}
+
+type GetMyAgendaResponseBody struct {
+	Items []AgendaItem `json:"items"`
+}
+
+type AgendaItem struct {
+	Kind          models.AgendaItemKind `json:"kind"                     enum:"workshop,activity"`
+	ID            int64                 `json:"id"                       doc:"Workshop or activity ID depending on kind"`
+	Title         string                `json:"title"`
+	EventDate     string                `json:"event_date"`
+	StartTime     time.Time             `json:"start_time"`
+	EndTime       time.Time             `json:"end_time"`
+	Location      string                `json:"location"`
+	Link          *string               `json:"link,omitempty"`
+	BookingStatus *models.Status        `json:"booking_status,omitempty" doc:"Only set for workshops"`
+	HasConflict   bool                  `json:"has_conflict"             doc:"The item overlaps another item of the agenda"`
+}
+
+func (h *agendaHandler) GetMyAgenda(ctx context.Context, input *GetMyAgendaRequest) (*GetMyAgendaResponse, error) {
+	userID, err := h.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	agenda, err := h.agendaUsecase.GetAgenda(ctx, userID)
+	if err != nil {
+		return nil, ErrInternalServerError(err)
+	}
+
+	items := make([]AgendaItem, 0, len(agenda))
+	for _, a := range agenda {
+		items = append(items, AgendaItem{
+			Kind:          a.Kind,
+			ID:            a.ID,
+			Title:         a.Title,
+			EventDate:     a.EventDate,
+			StartTime:     a.StartTime,
+			EndTime:       a.EndTime,
+			Location:      a.Location,
+			Link:          a.Link,
+			BookingStatus: a.BookingStatus,
+			HasConflict:   a.HasConflict,
+		})
+	}
+
+	return &GetMyAgendaResponse{
+		Body: GetMyAgendaResponseBody{
+			Items: items,
+		},
+	}, nil
+}
+
+type GetMyAgendaICSResponse struct {
+	ContentType        string `header:"Content-Type"`
+	ContentDisposition string `header:"Content-Disposition"`
+	Body               []byte
+}
+
+func (h *agendaHandler) GetMyAgendaICS(ctx context.Context, input *GetMyAgendaRequest) (*GetMyAgendaICSResponse, error) {
+	userID, err := h.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar, err := h.agendaUsecase.ExportAgendaICS(ctx, userID)
+	if err != nil {
+		return nil, ErrInternalServerError(err)
+	}
+
+	return &GetMyAgendaICSResponse{
+		ContentType:        ical.ContentType,
+		ContentDisposition: `attachment; filename="agenda.ics"`,
+		Body:               calendar,
+	}, nil
+}
+
+func (h *agendaHandler) getUserIDFromContext(ctx context.Context) (int64, error) {
+	email, ok := ctx.Value("email").(string)
+	if !ok || email == "" {
+		return 0, ErrEmailNotFound
+	}
+
+	user, err := h.userUsecase.GetUser(ctx, email, []string{"id"})
+	if err != nil {
+		if err == repositories.ErrUserNotFound {
+			return 0, ErrUserNotFound
+		}
+		return 0, ErrInternalServerError(err)
+	}
+
+	return user.ID, nil
+}
@@ -17,6 +17,23 @@ var (
 	ErrParticipantTypeNotAllowed = huma.Error403Forbidden("participant type is not allowed")
 	ErrAlreadyBooked             = huma.Error400BadRequest("already booked this workshop")
 	ErrBookingNotFound           = huma.Error404NotFound("booking not found")
+	ErrWorkshopNotFull           = huma.Error400BadRequest("workshop still has available seats, book it directly")
+	ErrAlreadyOnWaitlist         = huma.Error400BadRequest("already on the waitlist for this workshop")
+	ErrWaitlistEntryNotFound     = huma.Error404NotFound("waitlist entry not found")
+	ErrCancellationClosed        = huma.Error409Conflict("booking can no longer be cancelled this close to the workshop start")
+	ErrBookingLimitReached       = huma.Error409Conflict("maximum number of confirmed bookings reached")
+	ErrBookingSuspended          = huma.Error403Forbidden("booking is suspended after too many absences")
+	ErrBookingNotOpen            = huma.Error409Conflict("booking has not opened yet")
+	ErrBookingClosed             = huma.Error409Conflict("booking has closed")
+	ErrSeatsNotReleased          = huma.Error409Conflict("every released seat is taken, more seats are released later")
+	ErrSeatsReserved             = huma.Error409Conflict("remaining seats are reserved for other participant types")
+	ErrGroupLeaderNotAllowed     = huma.Error403Forbidden("only teachers and parents can make group bookings")
+	ErrGroupTooLarge             = huma.Error400BadRequest("group booking has too many companions")
+	ErrGroupSeatLimitReached     = huma.Error409Conflict("maximum number of group seats for this workshop reached")
+	ErrNotEnoughSeats            = huma.Error409Conflict("not enough seats left for the whole group")
+	ErrGroupBookingNotFound      = huma.Error404NotFound("group booking not found")
+	ErrGroupSeatNotFound         = huma.Error404NotFound("group seat not found")
+	ErrGroupSeatClaimed          = huma.Error409Conflict("group seat was already claimed")
 )
 
 type bookingHandler struct {
@@ -40,26 +57,106 @@ func InitBookingHandler(
 	bookingTag := "booking"
 
 	huma.Post(workshopGroup, "/{workshop_id}/book", handler.BookWorkshop, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(bookWorkshopErrorList)
 		o.Summary = "Book a workshop"
-		o.Description = "Create a booking for a workshop. Prevents double-booking and checks seat availability."
+		o.Description = "Create a booking for a workshop. Prevents double-booking and checks seat availability. If the workshop is full, join its waitlist instead. Users with too many confirmed bookings or absences cannot book." + errDoc
 		o.DefaultStatus = 201
 		o.Tags = []string{bookingTag}
+		o.Errors = errCodes
+		makeRateLimited(o, mid, usecases.RateLimitBook)
+		makeIdempotent(o, mid)
 	})
 
 	huma.Delete(workshopGroup, "/{workshop_id}/book", handler.CancelBooking, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(cancelBookingErrorList)
 		o.Summary = "Cancel a workshop booking"
-		o.Description = "Cancel an existing workshop booking"
+		o.Description = "Cancel an existing workshop booking, up to the cancellation cutoff before the workshop starts. The freed seat is given to the first eligible user on the workshop waitlist." + errDoc
 		o.DefaultStatus = 204
 		o.Tags = []string{bookingTag}
+		o.Errors = errCodes
 	})
 
 	huma.Get(userGroup, "/me/bookings", handler.GetMyBookings, func(o *huma.Operation) {
 		o.Summary = "Get my bookings"
-		o.Description = "Retrieve all bookings for the current user with workshop details. Sorted by status (Confirmed -> Attended -> Absent) then by date."
+		o.Description = "Retrieve all bookings for the current user with workshop details. Sorted by status (Confirmed -> Attended -> Absent) then by date. Waitlist entries are returned separately with their queue position."
 		o.Tags = []string{bookingTag}
 	})
+
+	huma.Post(workshopGroup, "/{workshop_id}/waitlist", handler.JoinWaitlist, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(joinWaitlistErrorList)
+		o.Summary = "Join a workshop waitlist"
+		o.Description = "Join the waitlist of a full workshop. When a seat is freed by a cancellation, the first eligible user in the queue is booked automatically." + errDoc
+		o.DefaultStatus = 201
+		o.Tags = []string{bookingTag}
+		o.Errors = errCodes
+	})
+
+	huma.Delete(workshopGroup, "/{workshop_id}/waitlist", handler.LeaveWaitlist, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(leaveWaitlistErrorList)
+		o.Summary = "Leave a workshop waitlist"
+		o.Description = "Remove the current user from the waitlist of a workshop" + errDoc
+		o.DefaultStatus = 204
+		o.Tags = []string{bookingTag}
+		o.Errors = errCodes
+	})
+
+	huma.Post(workshopGroup, "/{workshop_id}/group-bookings", handler.CreateGroupBooking, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(createGroupBookingErrorList)
+		o.Summary = "Book seats for a group"
+		o.Description = "Reserve one seat per named companion in a single transaction, either every seat is reserved or none. Only teachers and parents can make group bookings and they do not take a seat themselves. Each seat comes with a claim code the companion uses to move the seat to their own account. Seats count as `student` seats, so the group is refused when the remaining seats are reserved for other participant types. " +
+			"A leader holds a limited number of seats per workshop across their groups, and the booking policy limits of the leader apply." + errDoc
+		o.DefaultStatus = 201
+		o.Tags = []string{bookingTag}
+		o.Errors = errCodes
+		makeRateLimited(o, mid, usecases.RateLimitBook)
+	})
+
+	huma.Get(userGroup, "/me/group-bookings", handler.GetMyGroupBookings, func(o *huma.Operation) {
+		o.Summary = "Get my group bookings"
+		o.Description = "Retrieve the group bookings made by the current user with the status and claim code of every seat."
+		o.Tags = []string{bookingTag}
+	})
+
+	huma.Delete(userGroup, "/me/group-bookings/{group_id}", handler.CancelGroupBooking, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(cancelGroupBookingErrorList)
+		o.Summary = "Cancel a group booking"
+		o.Description = "Release every seat of a group booking that has not been claimed yet, up to the cancellation cutoff. Claimed seats are bookings of their companions and are kept." + errDoc
+		o.DefaultStatus = 204
+		o.Tags = []string{bookingTag}
+		o.Errors = errCodes
+	})
+
+	huma.Delete(userGroup, "/me/group-bookings/{group_id}/seats/{seat_id}", handler.CancelGroupSeat, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(cancelGroupSeatErrorList)
+		o.Summary = "Cancel a seat of a group booking"
+		o.Description = "Release one seat of a group booking that has not been claimed yet, up to the cancellation cutoff. The freed seat is given to the first eligible user on the workshop waitlist." + errDoc
+		o.DefaultStatus = 204
+		o.Tags = []string{bookingTag}
+		o.Errors = errCodes
+	})
+
+	huma.Post(userGroup, "/me/group-seats/claim", handler.ClaimGroupSeat, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(claimGroupSeatErrorList)
+		o.Summary = "Claim a group seat"
+		o.Description = "Turn a seat reserved by a group leader into a booking of the current user. The booking rules apply as for a normal booking except the booking window, since the seat is already held." + errDoc
+		o.DefaultStatus = 201
+		o.Tags = []string{bookingTag}
+		o.Errors = errCodes
+	})
 }
 
+var (
+	bookWorkshopErrorList  = []huma.StatusError{ErrEmailNotFound, ErrUserNotFound, ErrWorkshopNotFound, ErrBookingNotOpen, ErrBookingClosed, ErrWorkshopFull, ErrSeatsNotReleased, ErrSeatsReserved, ErrAlreadyBooked, ErrTimeConflict, ErrParticipantTypeNotAllowed, ErrBookingLimitReached, ErrBookingSuspended, ErrInternalServerError()}
+	cancelBookingErrorList = []huma.StatusError{ErrEmailNotFound, ErrUserNotFound, ErrBookingNotFound, ErrCancellationClosed, ErrInternalServerError()}
+	joinWaitlistErrorList  = []huma.StatusError{ErrEmailNotFound, ErrUserNotFound, ErrWorkshopNotFound, ErrBookingNotOpen, ErrBookingClosed, ErrWorkshopNotFull, ErrAlreadyBooked, ErrAlreadyOnWaitlist, ErrTimeConflict, ErrParticipantTypeNotAllowed, ErrBookingLimitReached, ErrBookingSuspended, ErrInternalServerError()}
+	leaveWaitlistErrorList = []huma.StatusError{ErrEmailNotFound, ErrUserNotFound, ErrWaitlistEntryNotFound, ErrInternalServerError()}
+
+	createGroupBookingErrorList = []huma.StatusError{ErrEmailNotFound, ErrUserNotFound, ErrWorkshopNotFound, ErrGroupLeaderNotAllowed, ErrGroupTooLarge, ErrGroupSeatLimitReached, ErrBookingLimitReached, ErrBookingSuspended, ErrBookingNotOpen, ErrBookingClosed, ErrParticipantTypeNotAllowed, ErrNotEnoughSeats, ErrSeatsNotReleased, ErrSeatsReserved, ErrInternalServerError()}
+	cancelGroupBookingErrorList = []huma.StatusError{ErrEmailNotFound, ErrUserNotFound, ErrGroupBookingNotFound, ErrCancellationClosed, ErrInternalServerError()}
+	cancelGroupSeatErrorList    = []huma.StatusError{ErrEmailNotFound, ErrUserNotFound, ErrGroupBookingNotFound, ErrGroupSeatNotFound, ErrGroupSeatClaimed, ErrCancellationClosed, ErrInternalServerError()}
+	claimGroupSeatErrorList     = []huma.StatusError{ErrEmailNotFound, ErrUserNotFound, ErrGroupSeatNotFound, ErrGroupSeatClaimed, ErrBookingClosed, ErrAlreadyBooked, ErrTimeConflict, ErrParticipantTypeNotAllowed, ErrBookingLimitReached, ErrBookingSuspended, ErrSeatsReserved, ErrInternalServerError()}
+)
+
 type BookWorkshopRequest struct {
 	WorkshopID int64 `path:"workshop_id"`
 }
@@ -85,12 +182,24 @@ func (h *bookingHandler) BookWorkshop(ctx context.Context, input *BookWorkshopRe
 			return nil, ErrWorkshopNotFound
 		case repositories.ErrWorkshopFull:
 			return nil, ErrWorkshopFull
+		case usecases.ErrSeatsNotReleased:
+			return nil, ErrSeatsNotReleased
+		case repositories.ErrSeatsReserved:
+			return nil, ErrSeatsReserved
 		case repositories.ErrAlreadyBooked:
 			return nil, ErrAlreadyBooked
 		case usecases.ErrTimeConflict:
 			return nil, ErrTimeConflict
 		case usecases.ErrParticipantTypeNotAllowed:
 			return nil, ErrParticipantTypeNotAllowed
+		case usecases.ErrBookingLimitReached:
+			return nil, ErrBookingLimitReached
+		case usecases.ErrBookingSuspended:
+			return nil, ErrBookingSuspended
+		case usecases.ErrBookingNotOpen:
+			return nil, ErrBookingNotOpen
+		case usecases.ErrBookingClosed:
+			return nil, ErrBookingClosed
 		default:
 			return nil, ErrInternalServerError(err)
 		}
@@ -118,6 +227,8 @@ func (h *bookingHandler) CancelBooking(ctx context.Context, input *CancelBooking
 		switch err {
 		case repositories.ErrBookingNotFound:
 			return nil, ErrBookingNotFound
+		case usecases.ErrCancellationClosed:
+			return nil, ErrCancellationClosed
 		default:
 			return nil, ErrInternalServerError(err)
 		}
@@ -133,7 +244,8 @@ type GetMyBookingsResponse struct {
 }
 
 type GetMyBookingsResponseBody struct {
-	Bookings []BookingItem `json:"bookings"`
+	Bookings []BookingItem  `json:"bookings"`
+	Waitlist []WaitlistItem `json:"waitlist"`
 }
 
 type BookingItem struct {
@@ -145,6 +257,13 @@ type BookingItem struct {
 	Workshop    BookingWorkshopInfo `json:"workshop"`
 }
 
+type WaitlistItem struct {
+	WorkshopID int64               `json:"workshop_id"`
+	Position   int                 `json:"position"  doc:"1-based position in the workshop's waitlist queue"`
+	JoinedAt   string              `json:"joined_at"`
+	Workshop   BookingWorkshopInfo `json:"workshop"`
+}
+
 type BookingWorkshopInfo struct {
 	Name            string    `json:"name"`
 	EventDate       string    `json:"event_date"`
@@ -192,13 +311,114 @@ func (h *bookingHandler) GetMyBookings(ctx context.Context, input *GetMyBookings
 		items = append(items, item)
 	}
 
+	entries, err := h.bookingUsecase.GetMyWaitlist(ctx, userID)
+	if err != nil {
+		return nil, ErrInternalServerError(err)
+	}
+
+	waitlist := make([]WaitlistItem, 0, len(entries))
+	for _, e := range entries {
+		waitlist = append(waitlist, WaitlistItem{
+			WorkshopID: e.WorkshopID,
+			Position:   e.Position,
+			JoinedAt:   e.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			Workshop: BookingWorkshopInfo{
+				Name:            e.WorkshopName,
+				EventDate:       e.EventDate,
+				StartTime:       e.StartTime,
+				EndTime:         e.EndTime,
+				Location:        e.Location,
+				Affiliation:     e.Affiliation,
+				RegisteredCount: e.RegisteredCount,
+				TotalSeats:      e.TotalSeats,
+			},
+		})
+	}
+
 	return &GetMyBookingsResponse{
 		Body: GetMyBookingsResponseBody{
 			Bookings: items,
+			Waitlist: waitlist,
 		},
 	}, nil
 }
 
+type JoinWaitlistRequest struct {
+	WorkshopID int64 `path:"workshop_id"`
+}
+
+type JoinWaitlistResponse struct {
+	Body *struct{}
+}
+
+func (h *bookingHandler) JoinWaitlist(ctx context.Context, input *JoinWaitlistRequest) (*JoinWaitlistResponse, error) {
+	userID, err := h.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userEmail, ok := ctx.Value("email").(string)
+	if !ok || userEmail == "" {
+		return nil, ErrEmailNotFound
+	}
+
+	err = h.bookingUsecase.JoinWaitlist(ctx, userID, userEmail, input.WorkshopID)
+	if err != nil {
+		switch err {
+		case repositories.ErrWorkshopNotFound:
+			return nil, ErrWorkshopNotFound
+		case usecases.ErrWorkshopNotFull:
+			return nil, ErrWorkshopNotFull
+		case repositories.ErrAlreadyBooked:
+			return nil, ErrAlreadyBooked
+		case repositories.ErrAlreadyOnWaitlist:
+			return nil, ErrAlreadyOnWaitlist
+		case usecases.ErrTimeConflict:
+			return nil, ErrTimeConflict
+		case usecases.ErrParticipantTypeNotAllowed:
+			return nil, ErrParticipantTypeNotAllowed
+		case usecases.ErrBookingLimitReached:
+			return nil, ErrBookingLimitReached
+		case usecases.ErrBookingSuspended:
+			return nil, ErrBookingSuspended
+		case usecases.ErrBookingNotOpen:
+			return nil, ErrBookingNotOpen
+		case usecases.ErrBookingClosed:
+			return nil, ErrBookingClosed
+		default:
+			return nil, ErrInternalServerError(err)
+		}
+	}
+
+	return &JoinWaitlistResponse{}, nil
+}
+
+type LeaveWaitlistRequest struct {
+	WorkshopID int64 `path:"workshop_id"`
+}
+
+type LeaveWaitlistResponse struct {
+	Body *struct{}
+}
+
+func (h *bookingHandler) LeaveWaitlist(ctx context.Context, input *LeaveWaitlistRequest) (*LeaveWaitlistResponse, error) {
+	userID, err := h.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.bookingUsecase.LeaveWaitlist(ctx, userID, input.WorkshopID)
+	if err != nil {
+		switch err {
+		case repositories.ErrWaitlistEntryNotFound:
+			return nil, ErrWaitlistEntryNotFound
+		default:
+			return nil, ErrInternalServerError(err)
+		}
+	}
+
+	return &LeaveWaitlistResponse{}, nil
+}
+
 func (h *bookingHandler) getUserIDFromContext(ctx context.Context) (int64, error) {
 	email, ok := ctx.Value("email").(string)
 	if !ok || email == "" {
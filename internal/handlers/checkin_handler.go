@@ -2,36 +2,50 @@ package handlers
 
 import (
 	"context"
-	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/middlewares"
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/qr"
 )
 
 var (
-	ErrInvalidCode   = huma.Error400BadRequest("invalid code")
-	ErrInvalidCodeFn = func(code string) huma.StatusError {
-		return huma.Error400BadRequest(fmt.Sprintf("invalid code %s", code))
-	}
-	ErrAlreadyCheckedIn = huma.Error400BadRequest("already checked in")
+	ErrInvalidCode           = huma.Error400BadRequest("invalid code")
+	ErrAlreadyCheckedIn      = huma.Error400BadRequest("already checked in")
+	ErrInvalidAttendeePass   = huma.Error400BadRequest("invalid or expired attendee pass")
+	ErrCheckInTargetRequired = huma.Error400BadRequest("exactly one of workshop_id or booth_id must be given")
+	ErrBoothNotFound         = huma.Error404NotFound("booth not found")
+	ErrNotBookedForWorkshop  = huma.Error404NotFound("attendee has no booking for this workshop")
+	ErrInsufficientStaffRole = huma.Error403Forbidden("insufficient staff role")
+	ErrStaffNotAssigned      = huma.Error403Forbidden("not assigned to this workshop or booth")
+	ErrInvalidBookingTicket  = huma.Error400BadRequest("invalid or expired booking ticket")
+	ErrTicketWrongWorkshop   = huma.Error409Conflict("booking ticket is for another workshop")
+	ErrBookingNotConfirmed   = huma.Error400BadRequest("booking is not confirmed")
+	ErrWorkshopEnded         = huma.Error400BadRequest("workshop already ended")
 )
 
 type checkInHandler struct {
-	checkInUsecase usecases.CheckInUsecase
-	mid            middlewares.Middleware
+	checkInUsecase   usecases.CheckInUsecase
+	rateLimitUsecase usecases.RateLimitUsecase
+	mid              middlewares.Middleware
 }
 
 func InitCheckInHandler(
 	checkInGroup huma.API,
+	userGroup huma.API,
+	adminGroup huma.API,
 	checkInUsecase usecases.CheckInUsecase,
+	rateLimitUsecase usecases.RateLimitUsecase,
 	mid middlewares.Middleware,
 ) {
 	handler := &checkInHandler{
-		checkInUsecase: checkInUsecase,
-		mid:            mid,
+		checkInUsecase:   checkInUsecase,
+		rateLimitUsecase: rateLimitUsecase,
+		mid:              mid,
 	}
 	checkInTag := "check-in"
 
@@ -39,7 +53,56 @@ func InitCheckInHandler(
 		errDoc, errCodes := buildErrorsDocumentation(checkInErrorList)
 
 		o.Summary = "Check-in with code"
-		o.Description = "The code should be formatted in `<type>-<uuid>` where `<type>` is either `W` for workshop or `B` for booth, and `<uuid>` is the identifier for workshop and booth"
+		o.Description = "The code should be formatted in `<type>-<uuid>` where `<type>` is either `W` for workshop or `B` for booth, and `<uuid>` is the identifier for workshop and booth. Users who enter too many invalid codes are locked out of check-in for a while."
+		o.Description += errDoc
+		o.DefaultStatus = 201
+		o.Tags = []string{checkInTag}
+		o.Errors = errCodes
+		makeRateLimited(o, mid, usecases.RateLimitCheckIn)
+		makeIdempotent(o, mid)
+	})
+
+	huma.Get(userGroup, "/me/pass", handler.GetAttendeePass, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(getAttendeePassErrorList)
+
+		o.Summary = "Get personal attendee pass"
+		o.Description = "Returns a short-lived signed token to be rendered as a QR code and scanned by staff at a workshop or booth. Request a new one once it expires."
+		o.Description += errDoc
+		o.DefaultStatus = 200
+		o.Tags = []string{checkInTag}
+		o.Errors = errCodes
+	})
+
+	huma.Get(userGroup, "/me/bookings/{id}/ticket", handler.GetBookingTicket, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(getBookingTicketErrorList)
+
+		o.Summary = "Get e-ticket QR code of a booking"
+		o.Description = "Returns a QR code (PNG or SVG) encoding a signed ticket for a confirmed booking, valid until the workshop ends. Staff verify it with `POST /admin/tickets/verify`."
+		o.Description += errDoc
+		o.DefaultStatus = 200
+		o.Tags = []string{checkInTag}
+		o.Errors = errCodes
+	})
+
+	huma.Post(adminGroup, "/tickets/verify", handler.VerifyBookingTicket, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(verifyBookingTicketErrorList)
+
+		o.Summary = "Check-in an attendee by scanning their booking e-ticket"
+		o.Description = "Marks the booking in the ticket as attended when the ticket is for the workshop given in `workshop_id`. " +
+			"Requires `workshop_host` role and, unless admin, an assignment to the workshop."
+		o.Description += errDoc
+		o.DefaultStatus = 201
+		o.Tags = []string{checkInTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleWorkshopHost)}
+	})
+
+	huma.Post(adminGroup, "/check-in", handler.StaffCheckIn, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(staffCheckInErrorList)
+
+		o.Summary = "Check-in an attendee by scanning their pass"
+		o.Description = "Give exactly one of `workshop_id` (requires the `workshop_host` role) or `booth_id` (requires the `booth_operator` role). " +
+			"Staff other than admins must be assigned to the workshop or booth with `POST /admin/staff-assignments`. Stamps are granted the same way as a self check-in."
 		o.Description += errDoc
 		o.DefaultStatus = 201
 		o.Tags = []string{checkInTag}
@@ -64,7 +127,61 @@ type CheckInResponseBody struct {
 	Category models.BoothCategory `json:"category" enum:"department,club,exhibition"`
 }
 
-var checkInErrorList = []huma.StatusError{ErrEmailNotFound, ErrInvalidCode, ErrAlreadyCheckedIn, ErrInternalServerError()}
+type GetAttendeePassResponse struct {
+	Body struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+}
+
+type StaffCheckInRequest struct {
+	Body struct {
+		Token      string `json:"token"`
+		WorkshopID *int64 `json:"workshop_id,omitempty"`
+		BoothID    *int64 `json:"booth_id,omitempty"`
+	}
+}
+
+type StaffCheckInResponse struct {
+	Body struct {
+		CheckInResponseBody
+		Attendee StaffCheckInAttendee `json:"attendee"`
+	}
+}
+
+type StaffCheckInAttendee struct {
+	ID        int64  `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+type GetBookingTicketRequest struct {
+	ID     int64  `path:"id"`
+	Format string `query:"format" default:"png" enum:"png,svg"`
+	Size   int    `query:"size"   default:"256" minimum:"128" maximum:"1024" doc:"Width and height in pixels, only used for PNG"`
+}
+
+type GetBookingTicketResponse struct {
+	ContentType  string    `header:"Content-Type"`
+	CacheControl string    `header:"Cache-Control"`
+	ExpiresAt    time.Time `header:"X-Ticket-Expires-At" doc:"The ticket is rejected after the workshop ends"`
+	Body         []byte
+}
+
+type VerifyBookingTicketRequest struct {
+	Body struct {
+		Token      string `json:"token"       doc:"Content of the scanned e-ticket QR code"`
+		WorkshopID int64  `json:"workshop_id" doc:"Workshop whose attendees are being checked in"`
+	}
+}
+
+var (
+	checkInErrorList             = []huma.StatusError{ErrEmailNotFound, ErrInvalidCode, ErrAlreadyCheckedIn, ErrInternalServerError()}
+	getAttendeePassErrorList     = []huma.StatusError{ErrEmailNotFound, ErrUserNotFound, ErrInternalServerError()}
+	staffCheckInErrorList        = []huma.StatusError{ErrEmailNotFound, ErrCheckInTargetRequired, ErrInsufficientStaffRole, ErrStaffNotAssigned, ErrInvalidAttendeePass, ErrUserNotFound, ErrNotBookedForWorkshop, ErrBoothNotFound, ErrAlreadyCheckedIn, ErrInternalServerError()}
+	getBookingTicketErrorList    = []huma.StatusError{ErrEmailNotFound, ErrUserNotFound, ErrBookingNotFound, ErrBookingNotConfirmed, ErrAlreadyCheckedIn, ErrWorkshopEnded, ErrInternalServerError()}
+	verifyBookingTicketErrorList = []huma.StatusError{ErrEmailNotFound, ErrStaffNotAssigned, ErrInvalidBookingTicket, ErrTicketWrongWorkshop, ErrUserNotFound, ErrBookingNotFound, ErrBookingNotConfirmed, ErrAlreadyCheckedIn, ErrInternalServerError()}
+)
 
 func (h *checkInHandler) CheckIn(ctx context.Context, input *CheckInRequest) (*CheckInResponse, error) {
 	email, ok := ctx.Value("email").(string)
@@ -75,22 +192,20 @@ func (h *checkInHandler) CheckIn(ctx context.Context, input *CheckInRequest) (*C
 	result, err := h.checkInUsecase.CheckIn(ctx, email, input.Body.Code)
 	if err != nil {
 		switch err {
-		case usecases.ErrInvalidCodeFormat:
-			return nil, ErrInvalidCodeFn(input.Body.Code + " (invalid code format) email = " + email)
+		// The attempted code is not echoed back, it would help guessing valid ones
+		case usecases.ErrInvalidCodeFormat, repositories.ErrInvalidCheckInCode, repositories.ErrBoothNotFound:
+			h.recordInvalidCheckIn(ctx)
+			return nil, ErrInvalidCode
 
 		// case workshop check-in
-		case repositories.ErrInvalidCheckInCode:
-			return nil, ErrInvalidCodeFn(input.Body.Code + " (invalid check in code) email = " + email)
 		case usecases.ErrAlreadyAttended:
 			return nil, ErrAlreadyCheckedIn
 		case repositories.ErrInvalidBookingStatus:
-			return nil, ErrInvalidCodeFn(input.Body.Code + " (invalid booking status) email = " + email)
+			return nil, ErrInvalidCode
 
 		// case booth check-in
 		case repositories.ErrUserNotFound:
 			return nil, ErrUserNotFound
-		case repositories.ErrBoothNotFound:
-			return nil, ErrInvalidCodeFn(input.Body.Code)
 		case repositories.ErrAlreadyCheckedInBooth:
 			return nil, ErrAlreadyCheckedIn
 
@@ -108,3 +223,184 @@ func (h *checkInHandler) CheckIn(ctx context.Context, input *CheckInRequest) (*C
 		},
 	}, nil
 }
+
+// recordInvalidCheckIn counts an invalid code towards the check-in lockout of the user. The attempt
+// already failed, so an error is only logged.
+func (h *checkInHandler) recordInvalidCheckIn(ctx context.Context) {
+	uid, _ := ctx.Value("uid").(string)
+	if err := h.rateLimitUsecase.RecordInvalidCheckIn(ctx, uid); err != nil {
+		slog.ErrorContext(ctx, "check-in: record invalid code", "error", err)
+	}
+}
+
+func (h *checkInHandler) GetAttendeePass(ctx context.Context, input *struct{}) (*GetAttendeePassResponse, error) {
+	email, ok := ctx.Value("email").(string)
+	if !ok || email == "" {
+		return nil, ErrEmailNotFound
+	}
+
+	pass, err := h.checkInUsecase.IssueAttendeePass(ctx, email)
+	if err != nil {
+		if err == repositories.ErrUserNotFound {
+			return nil, ErrUserNotFound
+		}
+		return nil, ErrInternalServerError(err)
+	}
+
+	resp := &GetAttendeePassResponse{}
+	resp.Body.Token = pass.Token
+	resp.Body.ExpiresAt = pass.ExpiresAt
+	return resp, nil
+}
+
+func (h *checkInHandler) StaffCheckIn(ctx context.Context, input *StaffCheckInRequest) (*StaffCheckInResponse, error) {
+	target := usecases.StaffCheckInTarget{
+		WorkshopID: input.Body.WorkshopID,
+		BoothID:    input.Body.BoothID,
+	}
+
+	if target.WorkshopID != nil && !middlewares.HasRole(ctx, models.StaffRoleWorkshopHost) {
+		return nil, ErrInsufficientStaffRole
+	}
+	if target.BoothID != nil && !middlewares.HasRole(ctx, models.StaffRoleBoothOperator) {
+		return nil, ErrInsufficientStaffRole
+	}
+	staff, err := staffMemberFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.checkInUsecase.StaffCheckIn(ctx, staff, input.Body.Token, target)
+	if err != nil {
+		switch err {
+		case usecases.ErrCheckInTargetRequired:
+			return nil, ErrCheckInTargetRequired
+		case usecases.ErrStaffNotAssigned:
+			return nil, ErrStaffNotAssigned
+		case usecases.ErrInvalidAttendeePass:
+			return nil, ErrInvalidAttendeePass
+		case repositories.ErrUserNotFound:
+			return nil, ErrUserNotFound
+		case repositories.ErrBookingNotFound, repositories.ErrInvalidBookingStatus:
+			return nil, ErrNotBookedForWorkshop
+		case repositories.ErrBoothNotFound:
+			return nil, ErrBoothNotFound
+		case usecases.ErrAlreadyAttended, repositories.ErrAlreadyCheckedInBooth:
+			return nil, ErrAlreadyCheckedIn
+		default:
+			return nil, ErrInternalServerError(err)
+		}
+	}
+
+	resp := &StaffCheckInResponse{}
+	resp.Body.CheckInResponseBody = CheckInResponseBody{
+		Type:     result.Type,
+		ID:       result.ID,
+		Name:     result.Name,
+		Category: result.Category,
+	}
+	resp.Body.Attendee = StaffCheckInAttendee{
+		ID:        result.UserID,
+		FirstName: result.FirstName,
+		LastName:  result.LastName,
+	}
+	return resp, nil
+}
+
+func (h *checkInHandler) GetBookingTicket(ctx context.Context, input *GetBookingTicketRequest) (*GetBookingTicketResponse, error) {
+	email, ok := ctx.Value("email").(string)
+	if !ok || email == "" {
+		return nil, ErrEmailNotFound
+	}
+
+	ticket, err := h.checkInUsecase.IssueBookingTicket(ctx, email, input.ID)
+	if err != nil {
+		switch err {
+		case repositories.ErrUserNotFound:
+			return nil, ErrUserNotFound
+		case repositories.ErrBookingNotFound:
+			return nil, ErrBookingNotFound
+		case repositories.ErrInvalidBookingStatus:
+			return nil, ErrBookingNotConfirmed
+		case usecases.ErrAlreadyAttended:
+			return nil, ErrAlreadyCheckedIn
+		case usecases.ErrWorkshopEnded:
+			return nil, ErrWorkshopEnded
+		default:
+			return nil, ErrInternalServerError(err)
+		}
+	}
+
+	resp := &GetBookingTicketResponse{
+		CacheControl: "no-store",
+		ExpiresAt:    ticket.ExpiresAt,
+	}
+	switch input.Format {
+	case "svg":
+		resp.ContentType = "image/svg+xml"
+		resp.Body, err = qr.SVG(ticket.Token)
+	default:
+		resp.ContentType = "image/png"
+		resp.Body, err = qr.PNG(ticket.Token, input.Size)
+	}
+	if err != nil {
+		return nil, ErrInternalServerError(err)
+	}
+
+	return resp, nil
+}
+
+func (h *checkInHandler) VerifyBookingTicket(ctx context.Context, input *VerifyBookingTicketRequest) (*StaffCheckInResponse, error) {
+	staff, err := staffMemberFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.checkInUsecase.VerifyBookingTicket(ctx, staff, input.Body.Token, input.Body.WorkshopID)
+	if err != nil {
+		switch err {
+		case usecases.ErrStaffNotAssigned:
+			return nil, ErrStaffNotAssigned
+		case usecases.ErrInvalidBookingTicket:
+			return nil, ErrInvalidBookingTicket
+		case usecases.ErrTicketWrongWorkshop:
+			return nil, ErrTicketWrongWorkshop
+		case repositories.ErrUserNotFound:
+			return nil, ErrUserNotFound
+		case repositories.ErrBookingNotFound:
+			return nil, ErrBookingNotFound
+		case repositories.ErrInvalidBookingStatus:
+			return nil, ErrBookingNotConfirmed
+		case usecases.ErrAlreadyAttended:
+			return nil, ErrAlreadyCheckedIn
+		default:
+			return nil, ErrInternalServerError(err)
+		}
+	}
+
+	resp := &StaffCheckInResponse{}
+	resp.Body.CheckInResponseBody = CheckInResponseBody{
+		Type:     result.Type,
+		ID:       result.ID,
+		Name:     result.Name,
+		Category: result.Category,
+	}
+	resp.Body.Attendee = StaffCheckInAttendee{
+		ID:        result.UserID,
+		FirstName: result.FirstName,
+		LastName:  result.LastName,
+	}
+	return resp, nil
+}
+
+// staffMemberFromContext returns the staff member resolved by WithAuthContext and RequireRole.
+func staffMemberFromContext(ctx context.Context) (usecases.StaffMember, error) {
+	email, ok := ctx.Value("email").(string)
+	if !ok || email == "" {
+		return usecases.StaffMember{}, ErrEmailNotFound
+	}
+	return usecases.StaffMember{
+		Email: email,
+		Admin: middlewares.HasRole(ctx, models.StaffRoleAdmin),
+	}, nil
+}
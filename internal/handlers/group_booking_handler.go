@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
+)
+
+// Group bookings are served by bookingHandler, the routes are registered in InitBookingHandler.
+
+type GroupBookingItem struct {
+	ID         int64             `json:"id"`
+	WorkshopID int64             `json:"workshop_id"`
+	CreatedAt  time.Time         `json:"created_at"`
+	Workshop   GroupWorkshopInfo `json:"workshop"`
+	Seats      []GroupSeatItem   `json:"seats"`
+}
+
+type GroupWorkshopInfo struct {
+	Name      string    `json:"name"`
+	EventDate string    `json:"event_date"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Location  string    `json:"location"`
+}
+
+type GroupSeatItem struct {
+	ID            int64                  `json:"id"`
+	CompanionName string                 `json:"companion_name"`
+	Status        models.GroupSeatStatus `json:"status"                   enum:"reserved,claimed,cancelled"`
+	ClaimCode     string                 `json:"claim_code"               doc:"Code the companion submits to claim the seat"`
+	BookingStatus *models.Status         `json:"booking_status,omitempty" doc:"Status of the booking of the companion once the seat is claimed"`
+}
+
+func toGroupBookingItem(g models.BookingGroupWithWorkshop) GroupBookingItem {
+	seats := make([]GroupSeatItem, 0, len(g.Seats))
+	for _, s := range g.Seats {
+		seats = append(seats, GroupSeatItem{
+			ID:            s.ID,
+			CompanionName: s.CompanionName,
+			Status:        s.Status,
+			ClaimCode:     s.ClaimCode,
+			BookingStatus: s.BookingStatus,
+		})
+	}
+
+	return GroupBookingItem{
+		ID:         g.ID,
+		WorkshopID: g.WorkshopID,
+		CreatedAt:  g.CreatedAt,
+		Workshop: GroupWorkshopInfo{
+			Name:      g.WorkshopName,
+			EventDate: g.EventDate,
+			StartTime: g.StartTime,
+			EndTime:   g.EndTime,
+			Location:  g.Location,
+		},
+		Seats: seats,
+	}
+}
+
+type CreateGroupBookingRequest struct {
+	WorkshopID int64 `path:"workshop_id"`
+	Body       struct {
+		Companions []GroupCompanion `json:"companions" minItems:"1"`
+	}
+}
+
+type GroupCompanion struct {
+	Name string `json:"name" minLength:"1" maxLength:"200" doc:"Full name of the student the seat is reserved for"`
+}
+
+type CreateGroupBookingResponse struct {
+	Body GroupBookingItem
+}
+
+func (h *bookingHandler) CreateGroupBooking(ctx context.Context, input *CreateGroupBookingRequest) (*CreateGroupBookingResponse, error) {
+	userID, err := h.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userEmail, ok := ctx.Value("email").(string)
+	if !ok || userEmail == "" {
+		return nil, ErrEmailNotFound
+	}
+
+	names := make([]string, 0, len(input.Body.Companions))
+	for _, c := range input.Body.Companions {
+		names = append(names, c.Name)
+	}
+
+	group, err := h.bookingUsecase.CreateGroupBooking(ctx, userID, userEmail, input.WorkshopID, names)
+	if err != nil {
+		switch err {
+		case repositories.ErrWorkshopNotFound:
+			return nil, ErrWorkshopNotFound
+		case usecases.ErrGroupLeaderNotAllowed:
+			return nil, ErrGroupLeaderNotAllowed
+		case usecases.ErrGroupTooLarge:
+			return nil, ErrGroupTooLarge
+		case usecases.ErrGroupSeatLimitReached:
+			return nil, ErrGroupSeatLimitReached
+		case usecases.ErrBookingLimitReached:
+			return nil, ErrBookingLimitReached
+		case usecases.ErrBookingSuspended:
+			return nil, ErrBookingSuspended
+		case usecases.ErrBookingNotOpen:
+			return nil, ErrBookingNotOpen
+		case usecases.ErrBookingClosed:
+			return nil, ErrBookingClosed
+		case usecases.ErrParticipantTypeNotAllowed:
+			return nil, ErrParticipantTypeNotAllowed
+		case usecases.ErrNotEnoughSeats:
+			return nil, ErrNotEnoughSeats
+		case usecases.ErrSeatsNotReleased:
+			return nil, ErrSeatsNotReleased
+		case repositories.ErrSeatsReserved:
+			return nil, ErrSeatsReserved
+		default:
+			return nil, ErrInternalServerError(err)
+		}
+	}
+
+	return &CreateGroupBookingResponse{
+		Body: toGroupBookingItem(*group),
+	}, nil
+}
+
+type GetMyGroupBookingsRequest struct{}
+
+type GetMyGroupBookingsResponse struct {
+	Body GetMyGroupBookingsResponseBody
+}
+
+type GetMyGroupBookingsResponseBody struct {
+	Groups []GroupBookingItem `json:"groups"`
+}
+
+func (h *bookingHandler) GetMyGroupBookings(ctx context.Context, input *GetMyGroupBookingsRequest) (*GetMyGroupBookingsResponse, error) {
+	userID, err := h.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := h.bookingUsecase.GetMyGroupBookings(ctx, userID)
+	if err != nil {
+		return nil, ErrInternalServerError(err)
+	}
+
+	items := make([]GroupBookingItem, 0, len(groups))
+	for _, g := range groups {
+		items = append(items, toGroupBookingItem(g))
+	}
+
+	return &GetMyGroupBookingsResponse{
+		Body: GetMyGroupBookingsResponseBody{
+			Groups: items,
+		},
+	}, nil
+}
+
+type CancelGroupBookingRequest struct {
+	GroupID int64 `path:"group_id"`
+}
+
+type CancelGroupBookingResponse struct {
+	Body *struct{}
+}
+
+func (h *bookingHandler) CancelGroupBooking(ctx context.Context, input *CancelGroupBookingRequest) (*CancelGroupBookingResponse, error) {
+	userID, err := h.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.bookingUsecase.CancelGroupBooking(ctx, userID, input.GroupID)
+	if err != nil {
+		return nil, mapGroupCancelErr(err)
+	}
+
+	return &CancelGroupBookingResponse{}, nil
+}
+
+type CancelGroupSeatRequest struct {
+	GroupID int64 `path:"group_id"`
+	SeatID  int64 `path:"seat_id"`
+}
+
+type CancelGroupSeatResponse struct {
+	Body *struct{}
+}
+
+func (h *bookingHandler) CancelGroupSeat(ctx context.Context, input *CancelGroupSeatRequest) (*CancelGroupSeatResponse, error) {
+	userID, err := h.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.bookingUsecase.CancelGroupSeat(ctx, userID, input.GroupID, input.SeatID)
+	if err != nil {
+		return nil, mapGroupCancelErr(err)
+	}
+
+	return &CancelGroupSeatResponse{}, nil
+}
+
+func mapGroupCancelErr(err error) error {
+	switch err {
+	// checkCancellationCutoff reports a missing workshop as a missing booking
+	case repositories.ErrGroupBookingNotFound, repositories.ErrBookingNotFound:
+		return ErrGroupBookingNotFound
+	case repositories.ErrGroupSeatNotFound:
+		return ErrGroupSeatNotFound
+	case usecases.ErrGroupSeatClaimed:
+		return ErrGroupSeatClaimed
+	case usecases.ErrCancellationClosed:
+		return ErrCancellationClosed
+	default:
+		return ErrInternalServerError(err)
+	}
+}
+
+type ClaimGroupSeatRequest struct {
+	Body struct {
+		ClaimCode string `json:"claim_code" format:"uuid"`
+	}
+}
+
+type ClaimGroupSeatResponse struct {
+	Body *struct{}
+}
+
+func (h *bookingHandler) ClaimGroupSeat(ctx context.Context, input *ClaimGroupSeatRequest) (*ClaimGroupSeatResponse, error) {
+	userID, err := h.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userEmail, ok := ctx.Value("email").(string)
+	if !ok || userEmail == "" {
+		return nil, ErrEmailNotFound
+	}
+
+	err = h.bookingUsecase.ClaimGroupSeat(ctx, userID, userEmail, input.Body.ClaimCode)
+	if err != nil {
+		switch err {
+		case repositories.ErrGroupSeatNotFound:
+			return nil, ErrGroupSeatNotFound
+		case usecases.ErrGroupSeatClaimed:
+			return nil, ErrGroupSeatClaimed
+		case usecases.ErrBookingClosed:
+			return nil, ErrBookingClosed
+		case repositories.ErrAlreadyBooked:
+			return nil, ErrAlreadyBooked
+		case usecases.ErrTimeConflict:
+			return nil, ErrTimeConflict
+		case usecases.ErrParticipantTypeNotAllowed:
+			return nil, ErrParticipantTypeNotAllowed
+		case usecases.ErrBookingLimitReached:
+			return nil, ErrBookingLimitReached
+		case usecases.ErrBookingSuspended:
+			return nil, ErrBookingSuspended
+		case repositories.ErrSeatsReserved:
+			return nil, ErrSeatsReserved
+		default:
+			return nil, ErrInternalServerError(err)
+		}
+	}
+
+	return &ClaimGroupSeatResponse{}, nil
+}
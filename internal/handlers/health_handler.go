@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
+)
+
+var (
+	ErrDatabaseUnavailable = huma.Error503ServiceUnavailable("database is unavailable")
+	ErrMigrationsPending   = huma.Error503ServiceUnavailable("database migrations are pending")
+)
+
+type healthHandler struct {
+	healthUsecase usecases.HealthUsecase
+}
+
+func InitHealthHandler(api huma.API, healthUsecase usecases.HealthUsecase) {
+	handler := &healthHandler{
+		healthUsecase: healthUsecase,
+	}
+	healthTag := "health"
+
+	huma.Get(api, "/healthz", handler.Live, func(o *huma.Operation) {
+		o.Summary = "Liveness"
+		o.Description = "Answers as long as the process serves HTTP, without touching dependencies."
+		o.Tags = []string{healthTag}
+	})
+
+	huma.Get(api, "/readyz", handler.Ready, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(readyErrorList)
+		o.Summary = "Readiness"
+		o.Description = "Checks that Postgres answers and that every migration of this revision is applied." + errDoc
+		o.Tags = []string{healthTag}
+		o.Errors = errCodes
+	})
+}
+
+var readyErrorList = []huma.StatusError{ErrDatabaseUnavailable, ErrMigrationsPending}
+
+type LiveResponse struct {
+	Body struct {
+		Status string `json:"status" example:"ok"`
+	}
+}
+
+func (h *healthHandler) Live(ctx context.Context, input *struct{}) (*LiveResponse, error) {
+	resp := &LiveResponse{}
+	resp.Body.Status = "ok"
+	return resp, nil
+}
+
+type ReadyResponse struct {
+	Body struct {
+		Status                   string `json:"status"                     example:"ok"`
+		MigrationVersion         int64  `json:"migration_version"`
+		ExpectedMigrationVersion int64  `json:"expected_migration_version"`
+	}
+}
+
+func (h *healthHandler) Ready(ctx context.Context, input *struct{}) (*ReadyResponse, error) {
+	readiness, err := h.healthUsecase.Ready(ctx)
+	if err != nil {
+		switch err {
+		case usecases.ErrMigrationsPending:
+			return nil, ErrMigrationsPending
+		default:
+			return nil, ErrDatabaseUnavailable
+		}
+	}
+
+	resp := &ReadyResponse{}
+	resp.Body.Status = "ok"
+	resp.Body.MigrationVersion = readiness.MigrationVersion
+	resp.Body.ExpectedMigrationVersion = readiness.ExpectedMigrationVersion
+	return resp, nil
+}
@@ -1,10 +1,15 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/middlewares"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
 )
 
 // Return string for describing error and unique status code
@@ -26,3 +31,56 @@ func buildErrorsDocumentation(errors []huma.StatusError) (errDoc string, errCode
 
 	return errDoc, errCodes
 }
+
+// mapImportErr maps a bulk import error with mapErr, prefixing the message with the index of the rejected item.
+func mapImportErr(err error, mapErr func(error) huma.StatusError) huma.StatusError {
+	var itemErr *usecases.ImportItemError
+	if errors.As(err, &itemErr) {
+		mapped := mapErr(itemErr.Err)
+		if mapped.GetStatus() >= 500 {
+			return mapped
+		}
+		return huma.NewError(mapped.GetStatus(), fmt.Sprintf("item %d: %s", itemErr.Index, mapped.Error()))
+	}
+	if err == usecases.ErrEmptyImport {
+		return ErrEmptyImport
+	}
+	return mapErr(err)
+}
+
+// makeRateLimited applies the RateLimit middleware of route to an operation and documents the 429 response.
+func makeRateLimited(o *huma.Operation, mid middlewares.Middleware, route usecases.RateLimitRoute) {
+	o.Middlewares = append(o.Middlewares, mid.RateLimit(route))
+	o.Description += fmt.Sprintf("\n- `%d`: Too many requests, retry after the number of seconds in the `Retry-After` header", http.StatusTooManyRequests)
+	if !slices.Contains(o.Errors, http.StatusTooManyRequests) {
+		o.Errors = append(o.Errors, http.StatusTooManyRequests)
+	}
+}
+
+// makeIdempotent applies the Idempotent middleware to an operation and documents the Idempotency-Key header.
+func makeIdempotent(o *huma.Operation, mid middlewares.Middleware) {
+	o.Middlewares = append(o.Middlewares, mid.Idempotent)
+	o.Parameters = append(o.Parameters, &huma.Param{
+		Name:        middlewares.IdempotencyKeyHeader,
+		In:          "header",
+		Description: "Client generated key, e.g. a UUID. Retries with the same key replay the first response instead of repeating the request.",
+		Schema:      &huma.Schema{Type: huma.TypeString, MaxLength: &idempotencyKeyMaxLength},
+	})
+	o.Description += fmt.Sprintf("\n- `%d`: Idempotency-Key header too long\n- `%d`: Request with this Idempotency-Key still in progress\n- `%d`: Idempotency-Key already used for a different request",
+		http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity)
+	for _, code := range []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity} {
+		if !slices.Contains(o.Errors, code) {
+			o.Errors = append(o.Errors, code)
+		}
+	}
+}
+
+var idempotencyKeyMaxLength = middlewares.IdempotencyKeyMaxLength
+
+// makeStreaming exempts an operation that streams its response from the request and write timeouts.
+func makeStreaming(o *huma.Operation) {
+	if o.Metadata == nil {
+		o.Metadata = map[string]any{}
+	}
+	o.Metadata[middlewares.StreamingMetadataKey] = true
+}
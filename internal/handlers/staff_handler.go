@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/middlewares"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
+)
+
+var (
+	ErrInvalidStaffRole         = huma.Error400BadRequest("invalid staff role")
+	ErrStaffRoleAlreadyAssigned = huma.Error400BadRequest("staff role already assigned")
+	ErrStaffRoleNotFound        = huma.Error404NotFound("staff role not found")
+	ErrStaffAlreadyAssigned     = huma.Error400BadRequest("staff already assigned to this workshop or booth")
+	ErrStaffAssignmentNotFound  = huma.Error404NotFound("staff assignment not found")
+	ErrAssignmentTargetRequired = huma.Error400BadRequest("exactly one of workshop_id or booth_id must be given")
+)
+
+type staffHandler struct {
+	staffUsecase usecases.StaffUsecase
+	mid          middlewares.Middleware
+}
+
+func InitStaffHandler(adminGroup huma.API, staffUsecase usecases.StaffUsecase, mid middlewares.Middleware) {
+	handler := &staffHandler{
+		staffUsecase: staffUsecase,
+		mid:          mid,
+	}
+	staffTag := "admin"
+
+	huma.Get(adminGroup, "/me", handler.GetMyRoles, func(o *huma.Operation) {
+		o.Summary = "Get my staff roles"
+		o.Description = "Retrieve the staff roles of the current user, based on the Authorization header."
+		o.Tags = []string{staffTag}
+	})
+
+	huma.Get(adminGroup, "/staff", handler.ListStaff, func(o *huma.Operation) {
+		o.Summary = "List staff roles"
+		o.Description = "Retrieve every staff role assignment. Requires `admin` role."
+		o.Tags = []string{staffTag}
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Post(adminGroup, "/staff", handler.AssignRole, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(assignRoleErrorList)
+		o.Summary = "Assign a staff role"
+		o.Description = "Grant a staff role to a Google account email. Requires `admin` role." + errDoc
+		o.DefaultStatus = 201
+		o.Tags = []string{staffTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Delete(adminGroup, "/staff/{email}/{role}", handler.RevokeRole, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(revokeRoleErrorList)
+		o.Summary = "Revoke a staff role"
+		o.Description = "Remove a staff role from a Google account email. Requires `admin` role." + errDoc
+		o.DefaultStatus = 204
+		o.Tags = []string{staffTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Get(adminGroup, "/staff-assignments", handler.ListAssignments, func(o *huma.Operation) {
+		o.Summary = "List staff assignments"
+		o.Description = "Retrieve the workshops and booths every staff member may check attendees into. Requires `admin` role."
+		o.Tags = []string{staffTag}
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Post(adminGroup, "/staff-assignments", handler.Assign, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(assignStaffErrorList)
+		o.Summary = "Assign staff to a workshop or booth"
+		o.Description = "Allow a workshop host to check attendees into a workshop, or a booth operator into a booth. Give exactly one of `workshop_id` or `booth_id`. Admins need no assignment. Requires `admin` role." + errDoc
+		o.DefaultStatus = 201
+		o.Tags = []string{staffTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+
+	huma.Delete(adminGroup, "/staff-assignments/{id}", handler.Unassign, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(unassignStaffErrorList)
+		o.Summary = "Remove a staff assignment"
+		o.Description = "Requires `admin` role." + errDoc
+		o.DefaultStatus = 204
+		o.Tags = []string{staffTag}
+		o.Errors = errCodes
+		o.Middlewares = huma.Middlewares{mid.RequireRole(models.StaffRoleAdmin)}
+	})
+}
+
+var (
+	assignRoleErrorList    = []huma.StatusError{ErrInvalidStaffRole, ErrStaffRoleAlreadyAssigned, ErrInternalServerError()}
+	revokeRoleErrorList    = []huma.StatusError{ErrStaffRoleNotFound, ErrInternalServerError()}
+	assignStaffErrorList   = []huma.StatusError{ErrAssignmentTargetRequired, ErrStaffAlreadyAssigned, ErrWorkshopNotFound, ErrBoothNotFound, ErrInternalServerError()}
+	unassignStaffErrorList = []huma.StatusError{ErrStaffAssignmentNotFound, ErrInternalServerError()}
+)
+
+type GetMyRolesRequest struct{}
+
+type GetMyRolesResponse struct {
+	Body GetMyRolesResponseBody
+}
+
+type GetMyRolesResponseBody struct {
+	Email string             `json:"email"`
+	Roles []models.StaffRole `json:"roles"`
+}
+
+func (h *staffHandler) GetMyRoles(ctx context.Context, input *GetMyRolesRequest) (*GetMyRolesResponse, error) {
+	email, ok := ctx.Value("email").(string)
+	if !ok || email == "" {
+		return nil, ErrEmailNotFound
+	}
+
+	roles, err := h.staffUsecase.GetRoles(ctx, email)
+	if err != nil {
+		return nil, ErrInternalServerError(err)
+	}
+
+	return &GetMyRolesResponse{
+		Body: GetMyRolesResponseBody{
+			Email: email,
+			Roles: roles,
+		},
+	}, nil
+}
+
+type ListStaffRequest struct{}
+
+type ListStaffResponse struct {
+	Body ListStaffResponseBody
+}
+
+type ListStaffResponseBody struct {
+	Staff []StaffRoleItem `json:"staff"`
+}
+
+type StaffRoleItem struct {
+	Email     string           `json:"email"`
+	Role      models.StaffRole `json:"role"       enum:"admin,workshop_host,booth_operator,registration_desk"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+func (h *staffHandler) ListStaff(ctx context.Context, input *ListStaffRequest) (*ListStaffResponse, error) {
+	assignments, err := h.staffUsecase.ListStaff(ctx)
+	if err != nil {
+		return nil, ErrInternalServerError(err)
+	}
+
+	items := make([]StaffRoleItem, 0, len(assignments))
+	for _, a := range assignments {
+		items = append(items, StaffRoleItem{
+			Email:     a.Email,
+			Role:      a.Role,
+			CreatedAt: a.CreatedAt,
+		})
+	}
+
+	return &ListStaffResponse{
+		Body: ListStaffResponseBody{
+			Staff: items,
+		},
+	}, nil
+}
+
+type AssignRoleRequest struct {
+	Body struct {
+		Email string           `json:"email" format:"email"`
+		Role  models.StaffRole `json:"role"  enum:"admin,workshop_host,booth_operator,registration_desk"`
+	}
+}
+
+type AssignRoleResponse struct {
+	Body StaffRoleItem
+}
+
+func (h *staffHandler) AssignRole(ctx context.Context, input *AssignRoleRequest) (*AssignRoleResponse, error) {
+	assignment, err := h.staffUsecase.AssignRole(ctx, input.Body.Email, input.Body.Role)
+	if err != nil {
+		switch err {
+		case usecases.ErrInvalidStaffRole:
+			return nil, ErrInvalidStaffRole
+		case repositories.ErrStaffRoleAlreadyAssigned:
+			return nil, ErrStaffRoleAlreadyAssigned
+		default:
+			return nil, ErrInternalServerError(err)
+		}
+	}
+
+	return &AssignRoleResponse{
+		Body: StaffRoleItem{
+			Email:     assignment.Email,
+			Role:      assignment.Role,
+			CreatedAt: assignment.CreatedAt,
+		},
+	}, nil
+}
+
+type RevokeRoleRequest struct {
+	Email string           `path:"email"`
+	Role  models.StaffRole `path:"role"  enum:"admin,workshop_host,booth_operator,registration_desk"`
+}
+
+type RevokeRoleResponse struct {
+	Body *struct{}
+}
+
+func (h *staffHandler) RevokeRole(ctx context.Context, input *RevokeRoleRequest) (*RevokeRoleResponse, error) {
+	err := h.staffUsecase.RevokeRole(ctx, input.Email, input.Role)
+	if err != nil {
+		switch err {
+		case repositories.ErrStaffRoleNotFound:
+			return nil, ErrStaffRoleNotFound
+		default:
+			return nil, ErrInternalServerError(err)
+		}
+	}
+
+	return &RevokeRoleResponse{}, nil
+}
+
+type StaffAssignmentItem struct {
+	ID         int64     `json:"id"`
+	Email      string    `json:"email"`
+	WorkshopID *int64    `json:"workshop_id,omitempty"`
+	BoothID    *int64    `json:"booth_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func toStaffAssignmentItem(a models.StaffAssignment) StaffAssignmentItem {
+	return StaffAssignmentItem{
+		ID:         a.ID,
+		Email:      a.Email,
+		WorkshopID: a.WorkshopID,
+		BoothID:    a.BoothID,
+		CreatedAt:  a.CreatedAt,
+	}
+}
+
+type ListAssignmentsResponse struct {
+	Body struct {
+		Assignments []StaffAssignmentItem `json:"assignments"`
+	}
+}
+
+func (h *staffHandler) ListAssignments(ctx context.Context, input *struct{}) (*ListAssignmentsResponse, error) {
+	assignments, err := h.staffUsecase.ListAssignments(ctx)
+	if err != nil {
+		return nil, ErrInternalServerError(err)
+	}
+
+	resp := &ListAssignmentsResponse{}
+	resp.Body.Assignments = make([]StaffAssignmentItem, 0, len(assignments))
+	for _, a := range assignments {
+		resp.Body.Assignments = append(resp.Body.Assignments, toStaffAssignmentItem(a))
+	}
+	return resp, nil
+}
+
+type AssignStaffRequest struct {
+	Body struct {
+		Email      string `json:"email"                 format:"email"`
+		WorkshopID *int64 `json:"workshop_id,omitempty"`
+		BoothID    *int64 `json:"booth_id,omitempty"`
+	}
+}
+
+type AssignStaffResponse struct {
+	Body StaffAssignmentItem
+}
+
+func (h *staffHandler) Assign(ctx context.Context, input *AssignStaffRequest) (*AssignStaffResponse, error) {
+	assignment, err := h.staffUsecase.Assign(ctx, input.Body.Email, input.Body.WorkshopID, input.Body.BoothID)
+	if err != nil {
+		switch err {
+		case usecases.ErrCheckInTargetRequired:
+			return nil, ErrAssignmentTargetRequired
+		case repositories.ErrStaffAlreadyAssigned:
+			return nil, ErrStaffAlreadyAssigned
+		case repositories.ErrWorkshopNotFound:
+			return nil, ErrWorkshopNotFound
+		case repositories.ErrBoothNotFound:
+			return nil, ErrBoothNotFound
+		default:
+			return nil, ErrInternalServerError(err)
+		}
+	}
+
+	return &AssignStaffResponse{Body: toStaffAssignmentItem(*assignment)}, nil
+}
+
+type UnassignStaffRequest struct {
+	ID int64 `path:"id"`
+}
+
+type UnassignStaffResponse struct {
+	Body *struct{}
+}
+
+func (h *staffHandler) Unassign(ctx context.Context, input *UnassignStaffRequest) (*UnassignStaffResponse, error) {
+	if err := h.staffUsecase.Unassign(ctx, input.ID); err != nil {
+		if err == repositories.ErrStaffAssignmentNotFound {
+			return nil, ErrStaffAssignmentNotFound
+		}
+		return nil, ErrInternalServerError(err)
+	}
+
+	return &UnassignStaffResponse{}, nil
+}
@@ -64,6 +64,8 @@ func InitStampHandler(
 		o.DefaultStatus = 200
 		o.Tags = []string{stampTag}
 		o.Errors = errCodes
+		makeRateLimited(o, mid, usecases.RateLimitRedemption)
+		makeIdempotent(o, mid)
 	})
 }
 
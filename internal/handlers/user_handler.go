@@ -3,7 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
-	"log"
+	"log/slog"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/middlewares"
@@ -29,6 +29,7 @@ var (
 	ErrUserNotFound            = huma.Error404NotFound("user not found")
 	ErrUserAlreadyExists       = huma.Error400BadRequest("user already exists")
 	ErrProfileInfoNotFound     = huma.Error404NotFound("google profile info is not found")
+	ErrParticipantTypeInUse    = huma.Error409Conflict("participant type change conflicts with existing bookings, cancel them first")
 )
 
 type userHandler struct {
@@ -60,8 +61,33 @@ func InitUserHandler(api huma.API, usecase usecases.UserUsecase, stampUsecase us
 		o.Tags = []string{userTag}
 	})
 
+	huma.Patch(api, "/me", handler.UpdateUser, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(updateUserErrorList)
+		o.Summary = "Update user details"
+		o.Description = "Partially update the current user, only provided fields are changed. Extra attributes must be given again when changing the participant type." + errDoc
+		o.Tags = []string{userTag}
+		o.Errors = errCodes
+	})
+
+	huma.Delete(api, "/me", handler.DeleteUser, func(o *huma.Operation) {
+		errDoc, errCodes := buildErrorsDocumentation(deleteUserErrorList)
+		o.Summary = "Delete account"
+		o.Description = "Cancel every booking and waitlist place of the current user and erase their personal data. The same Google account can register again afterwards." + errDoc
+		o.DefaultStatus = 204
+		o.Tags = []string{userTag}
+		o.Errors = errCodes
+	})
 }
 
+var (
+	updateUserErrorList = []huma.StatusError{
+		ErrEmailNotFound, ErrUserNotFound, ErrNothingToUpdate, ErrExtraAttributesRequired, ErrExtraAttributesInvalid,
+		ErrAttendanceDateInvalid, ErrInvalidGender, ErrInvalidParticipantType, ErrInvalidTransportMode, ErrInvalidOriginLocation,
+		ErrParticipantTypeInUse, ErrInternalServerError(),
+	}
+	deleteUserErrorList = []huma.StatusError{ErrEmailNotFound, ErrUserNotFound, ErrInternalServerError()}
+)
+
 // Request and Response structs
 type CreateUserRequest struct {
 	Body struct {
@@ -185,7 +211,7 @@ func (h *userHandler) GetUser(ctx context.Context, input *GetUserRequest) (*GetU
 	}
 
 	fields := input.Fields
-	log.Println(fields)
+	slog.DebugContext(ctx, "get user", "fields", fields)
 	// default
 	if len(fields) == 0 {
 		fields = []string{"email"}
@@ -230,3 +256,113 @@ func (h *userHandler) GetUser(ctx context.Context, input *GetUserRequest) (*GetU
 		},
 	}, nil
 }
+
+type UpdateUserRequest struct {
+	Body struct {
+		FirstName       *string                 `json:"first_name,omitempty"`
+		LastName        *string                 `json:"last_name,omitempty"`
+		Gender          *models.Gender          `json:"gender,omitempty"`
+		PhoneNumber     *string                 `json:"phone_number,omitempty"`
+		ParticipantType *models.ParticipantType `json:"participant_type,omitempty"`
+		TransportMode   *models.TransportMode   `json:"transport_mode,omitempty"`
+		IsFromBangkok   *bool                   `json:"is_from_bangkok,omitempty"`
+		OriginLocation  *models.OriginLocation  `json:"origin_location,omitempty"`
+
+		AttendanceDates      []string `json:"attendance_dates,omitempty" doc:"date in format 2024-12-31"`
+		InterestedActivities []string `json:"interested_activities,omitempty"`
+		DiscoveryChannel     []string `json:"discovery_channel,omitempty"`
+
+		models.ExtraAttributesFields
+	}
+}
+
+func (h *userHandler) UpdateUser(ctx context.Context, input *UpdateUserRequest) (*GetUserResponse, error) {
+	email, ok := ctx.Value("email").(string)
+	if !ok || email == "" {
+		return nil, ErrEmailNotFound
+	}
+
+	update := &models.UserOptional{
+		FirstName:            input.Body.FirstName,
+		LastName:             input.Body.LastName,
+		Gender:               input.Body.Gender,
+		PhoneNumber:          input.Body.PhoneNumber,
+		ParticipantType:      input.Body.ParticipantType,
+		TransportMode:        input.Body.TransportMode,
+		IsFromBangkok:        input.Body.IsFromBangkok,
+		OriginLocation:       input.Body.OriginLocation,
+		AttendanceDates:      input.Body.AttendanceDates,
+		InterestedActivities: input.Body.InterestedActivities,
+		DiscoveryChannel:     input.Body.DiscoveryChannel,
+	}
+	if input.Body.ExtraAttributesFields != (models.ExtraAttributesFields{}) {
+		update.ExtraAttributes = &input.Body.ExtraAttributesFields
+	}
+
+	user, err := h.usecase.UpdateUser(ctx, email, update)
+	if err != nil {
+		switch err {
+		case repositories.ErrUserNotFound:
+			return nil, ErrUserNotFound
+		case usecases.ErrNothingToUpdate:
+			return nil, ErrNothingToUpdate
+		case myValidator.ErrExtraAttributesRequired:
+			return nil, ErrExtraAttributesRequired
+		case myValidator.ErrExtraAttributesInvalid:
+			return nil, ErrExtraAttributesInvalid
+		case usecases.ErrInvalidAttendanceDate:
+			return nil, ErrAttendanceDateInvalid
+		case myValidator.ErrInvalidGender:
+			return nil, ErrInvalidGender
+		case myValidator.ErrInvalidParticipantType:
+			return nil, ErrInvalidParticipantType
+		case myValidator.ErrInvalidTransportMode:
+			return nil, ErrInvalidTransportMode
+		case myValidator.ErrInvalidOriginLocation:
+			return nil, ErrInvalidOriginLocation
+		case usecases.ErrParticipantTypeInUse:
+			return nil, ErrParticipantTypeInUse
+		default:
+			return nil, ErrInternalServerError(err)
+		}
+	}
+
+	return &GetUserResponse{
+		Body: GetUserResponseBody{
+			ID:                   user.ID,
+			Email:                user.Email,
+			FirstName:            user.FirstName,
+			LastName:             user.LastName,
+			Gender:               user.Gender,
+			PhoneNumber:          user.PhoneNumber,
+			ParticipantType:      user.ParticipantType,
+			TransportMode:        user.TransportMode,
+			IsFromBangkok:        user.IsFromBangkok,
+			OriginLocation:       user.OriginLocation,
+			AttendanceDates:      user.AttendanceDates,
+			InterestedActivities: user.InterestedActivities,
+			DiscoveryChannel:     user.DiscoveryChannel,
+			ExtraAttributes:      user.ExtraAttributes,
+		},
+	}, nil
+}
+
+type DeleteUserResponse struct {
+	Body *struct{}
+}
+
+func (h *userHandler) DeleteUser(ctx context.Context, input *struct{}) (*DeleteUserResponse, error) {
+	email, ok := ctx.Value("email").(string)
+	if !ok || email == "" {
+		return nil, ErrEmailNotFound
+	}
+
+	if err := h.usecase.DeleteUser(ctx, email); err != nil {
+		if err == repositories.ErrUserNotFound {
+			return nil, ErrUserNotFound
+		}
+		return nil, ErrInternalServerError(err)
+	}
+
+	return &DeleteUserResponse{}, nil
+}
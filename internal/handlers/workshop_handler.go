@@ -127,18 +127,21 @@ type ListWorkshopResponseBody struct {
 	Workshops []WorkshopItem `json:"workshops"`
 }
 type WorkshopItem struct {
-	ID              int64                   `json:"id"`
-	Name            string                  `json:"name"`
-	Description     string                  `json:"description"`
-	Category        models.WorkShopCategory `json:"category"`
-	Affiliation     string                  `json:"affiliation"`
-	EventDate       string                  `json:"event_date"`
-	StartTime       time.Time               `json:"start_time"`
-	EndTime         time.Time               `json:"end_time"`
-	Location        string                  `json:"location"`
-	TotalSeats      int                     `json:"total_seats"`
-	RegisteredCount int                     `json:"registered_count"`
-	Image           string                  `json:"image"`
+	ID                int64                   `json:"id"`
+	Name              string                  `json:"name"`
+	Description       string                  `json:"description"`
+	Category          models.WorkShopCategory `json:"category"`
+	Affiliation       string                  `json:"affiliation"`
+	EventDate         string                  `json:"event_date"`
+	StartTime         time.Time               `json:"start_time"`
+	EndTime           time.Time               `json:"end_time"`
+	Location          string                  `json:"location"`
+	TotalSeats        int                     `json:"total_seats"`
+	RegisteredCount   int                     `json:"registered_count"`
+	Image             string                  `json:"image"`
+	BookingOpensAt    *time.Time              `json:"booking_opens_at"    doc:"Booking is open right away when null"`
+	BookingClosesAt   *time.Time              `json:"booking_closes_at"   doc:"Booking never closes when null"`
+	AvailableSeatsNow int                     `json:"available_seats_now" doc:"Seats that can be booked right now, seats of a later staged release and a closed booking window count as unavailable"`
 }
 
 func (h *workshopHandler) ListWorkshop(ctx context.Context, input *ListWorkshopRequest) (*ListWorkshopResponse, error) {
@@ -174,18 +177,21 @@ func (h *workshopHandler) ListWorkshop(ctx context.Context, input *ListWorkshopR
 
 	for _, w := range workshops {
 		items = append(items, WorkshopItem{
-			ID:              w.ID,
-			Name:            w.Name,
-			Description:     w.Description,
-			Category:        w.Category,
-			Affiliation:     w.Affiliation,
-			EventDate:       w.EventDate,
-			StartTime:       w.StartTime,
-			EndTime:         w.EndTime,
-			Location:        w.Location,
-			TotalSeats:      w.TotalSeats,
-			RegisteredCount: w.RegisteredCount,
-			Image:           w.Image,
+			ID:                w.ID,
+			Name:              w.Name,
+			Description:       w.Description,
+			Category:          w.Category,
+			Affiliation:       w.Affiliation,
+			EventDate:         w.EventDate,
+			StartTime:         w.StartTime,
+			EndTime:           w.EndTime,
+			Location:          w.Location,
+			TotalSeats:        w.TotalSeats,
+			RegisteredCount:   w.RegisteredCount,
+			Image:             w.Image,
+			BookingOpensAt:    w.BookingOpensAt,
+			BookingClosesAt:   w.BookingClosesAt,
+			AvailableSeatsNow: w.AvailableSeatsNow,
 		})
 	}
 
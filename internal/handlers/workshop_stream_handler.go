@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/sse"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/middlewares"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
+)
+
+// seatStreamPingInterval keeps idle connections from being closed by proxies.
+const seatStreamPingInterval = 25 * time.Second
+
+type workshopStreamHandler struct {
+	seatStreamUsecase usecases.SeatStreamUsecase
+	mid               middlewares.Middleware
+}
+
+func InitWorkshopStreamHandler(workshopStreamGroup huma.API, seatStreamUsecase usecases.SeatStreamUsecase, mid middlewares.Middleware) {
+	handler := &workshopStreamHandler{
+		seatStreamUsecase: seatStreamUsecase,
+		mid:               mid,
+	}
+
+	op := huma.Operation{
+		OperationID: "stream-workshop-seats",
+		Method:      http.MethodGet,
+		Path:        "/stream",
+		Summary:     "Stream seat availability",
+		Description: "Server-Sent Events stream. A `snapshot` event with every workshop is sent first, " +
+			"then a `seats` event whenever the seats of a workshop change. `ping` is sent periodically to keep the connection open. " +
+			"No authentication, so it can be opened with `EventSource`.",
+		Tags: []string{"workshop"},
+	}
+	makeStreaming(&op)
+
+	sse.Register(workshopStreamGroup, op, map[string]any{
+		"snapshot": SeatSnapshotEvent{},
+		"seats":    models.WorkshopSeatUpdate{},
+		"ping":     SeatPingEvent{},
+	}, handler.StreamSeats)
+}
+
+type SeatSnapshotEvent struct {
+	Workshops []models.WorkshopSeatUpdate `json:"workshops"`
+}
+
+type SeatPingEvent struct {
+	Time time.Time `json:"time"`
+}
+
+func (h *workshopStreamHandler) StreamSeats(ctx context.Context, input *struct{}, send sse.Sender) {
+	// Subscribe before taking the snapshot so no update falls in between
+	updates, unsubscribe := h.seatStreamUsecase.Subscribe()
+	defer unsubscribe()
+
+	seats, err := h.seatStreamUsecase.Snapshot(ctx)
+	if err != nil {
+		return
+	}
+	if err := send.Data(SeatSnapshotEvent{Workshops: seats}); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(seatStreamPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case update, ok := <-updates:
+			// The stream is closed when the server shuts down
+			if !ok {
+				return
+			}
+			if err := send.Data(update); err != nil {
+				return
+			}
+		case now := <-ticker.C:
+			if err := send.Data(SeatPingEvent{Time: now}); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "openhouse"
+
+// Registry holds every collector of the API, served by Handler.
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests by Huma operation ID, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation", "method", "status"})
+
+	BookingsCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bookings_created_total",
+		Help:      "Bookings created, by source: direct or waitlist promotion.",
+	}, []string{"source"})
+
+	BookingConflicts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "booking_conflicts_total",
+		Help:      "Booking attempts rejected by a booking rule, by error type.",
+	}, []string{"error"})
+
+	CheckIns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "check_ins_total",
+		Help:      "Check-ins by type: workshop or booth.",
+	}, []string{"type"})
+
+	Redemptions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "stamp_redemptions_total",
+		Help:      "Stamp poster redemptions by stamp type.",
+	}, []string{"stamp_type"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		BookingsCreated,
+		BookingConflicts,
+		CheckIns,
+		Redemptions,
+	)
+}
+
+// RegisterDB exports the connection pool stats of db.
+func RegisterDB(db *sql.DB) {
+	Registry.MustRegister(collectors.NewDBStatsCollector(db, "postgres"))
+}
+
+// Handler serves the registry in the Prometheus exposition format. When token is not empty requests
+// must carry it as a bearer token.
+func Handler(token string) http.Handler {
+	handler := promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+	if token == "" {
+		return handler
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, []byte("Bearer "+token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,169 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	IdempotencyKeyMaxLength   = 255
+	idempotencyDefaultMaxBody = 1024 * 1024
+)
+
+// Idempotent replays the first response to a request made with the same Idempotency-Key header by the
+// same user, requests without the header are handled as usual. It must be applied after WithAuthContext.
+// Server errors are not kept, so a retry after one is handled again.
+func (m *middlewareImpl) Idempotent(ctx huma.Context, next func(huma.Context)) {
+	key := ctx.Header(IdempotencyKeyHeader)
+	if key == "" {
+		next(ctx)
+		return
+	}
+	if len(key) > IdempotencyKeyMaxLength {
+		huma.WriteErr(m.api, ctx, http.StatusBadRequest, "Idempotency-Key header must be at most "+strconv.Itoa(IdempotencyKeyMaxLength)+" characters")
+		return
+	}
+
+	email, ok := ctx.Context().Value("email").(string)
+	if !ok || email == "" {
+		huma.WriteErr(m.api, ctx, http.StatusUnauthorized, "email not found in context")
+		return
+	}
+
+	// The body is read here to fingerprint the request and handed to the operation from memory, one byte
+	// past the operation body limit is read to reject a larger body instead of truncating it
+	maxBody := int64(idempotencyDefaultMaxBody)
+	if op := ctx.Operation(); op != nil && op.MaxBodyBytes > 0 {
+		maxBody = op.MaxBodyBytes
+	}
+	var body []byte
+	if reader := ctx.BodyReader(); reader != nil {
+		var err error
+		body, err = io.ReadAll(io.LimitReader(reader, maxBody+1))
+		if err != nil {
+			huma.WriteErr(m.api, ctx, http.StatusBadRequest, "cannot read request body", err)
+			return
+		}
+		if int64(len(body)) > maxBody {
+			huma.WriteErr(m.api, ctx, http.StatusRequestEntityTooLarge, "request body is too large, limit = "+strconv.FormatInt(maxBody, 10)+" bytes")
+			return
+		}
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(ctx.Method() + " " + ctx.URL().Path + "\n"))
+	hash.Write(body)
+	fingerprint := hex.EncodeToString(hash.Sum(nil))
+
+	record, err := m.idempotencyUsecase.Begin(ctx.Context(), email, key, fingerprint)
+	if err != nil {
+		switch err {
+		case usecases.ErrIdempotencyKeyReused:
+			huma.WriteErr(m.api, ctx, http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+		case usecases.ErrIdempotencyKeyInProgress:
+			huma.WriteErr(m.api, ctx, http.StatusConflict, "a request with this Idempotency-Key is still in progress")
+		default:
+			huma.WriteErr(m.api, ctx, http.StatusInternalServerError, "internal server error", err)
+		}
+		return
+	}
+
+	if record != nil {
+		if record.ContentType != nil && *record.ContentType != "" {
+			ctx.SetHeader("Content-Type", *record.ContentType)
+		}
+		ctx.SetHeader(IdempotentReplayedHeader, "true")
+		ctx.SetStatus(*record.StatusCode)
+		if len(record.ResponseBody) > 0 {
+			ctx.BodyWriter().Write(record.ResponseBody)
+		}
+		return
+	}
+
+	// Storing the outcome must not depend on the client still waiting for it
+	storeCtx := context.WithoutCancel(ctx.Context())
+	rc := &recordingContext{
+		humaContext: ctx,
+		body:        bytes.NewReader(body),
+	}
+
+	completed := false
+	defer func() {
+		if completed {
+			return
+		}
+		if err := m.idempotencyUsecase.Release(storeCtx, email, key); err != nil {
+			logIdempotencyErr(ctx, err)
+		}
+	}()
+
+	next(rc)
+
+	status := rc.Status()
+	if status == 0 {
+		status = http.StatusOK
+	}
+	if status >= http.StatusInternalServerError {
+		return
+	}
+	if err := m.idempotencyUsecase.Complete(storeCtx, email, key, status, rc.contentType, rc.response.Bytes()); err != nil {
+		logIdempotencyErr(ctx, err)
+		return
+	}
+	completed = true
+}
+
+// logIdempotencyErr logs a failure to store the outcome, the response itself has already been written.
+func logIdempotencyErr(ctx huma.Context, err error) {
+	slog.ErrorContext(ctx.Context(), "idempotency: store outcome", "error", err)
+}
+
+// humaContext lets recordingContext embed huma.Context, whose Context method clashes with the field name.
+type humaContext = huma.Context
+
+// recordingContext serves the buffered request body and copies the response as it is written.
+type recordingContext struct {
+	humaContext
+	body        io.Reader
+	status      int
+	contentType string
+	response    bytes.Buffer
+}
+
+func (c *recordingContext) BodyReader() io.Reader {
+	return c.body
+}
+
+func (c *recordingContext) SetStatus(code int) {
+	c.status = code
+	c.humaContext.SetStatus(code)
+}
+
+func (c *recordingContext) Status() int {
+	if c.status == 0 {
+		return c.humaContext.Status()
+	}
+	return c.status
+}
+
+func (c *recordingContext) SetHeader(name, value string) {
+	if http.CanonicalHeaderKey(name) == "Content-Type" {
+		c.contentType = value
+	}
+	c.humaContext.SetHeader(name, value)
+}
+
+func (c *recordingContext) BodyWriter() io.Writer {
+	return io.MultiWriter(c.humaContext.BodyWriter(), &c.response)
+}
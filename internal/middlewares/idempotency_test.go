@@ -0,0 +1,187 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/config"
+)
+
+// fakeIdempotencyRepo keeps the keys in memory on a fake clock. An in-progress key with the same fingerprint
+// is taken over once its lease ran out, crashed drops releases as if the process died before them.
+type fakeIdempotencyRepo struct {
+	repositories.IdempotencyRepo
+	records map[string]*models.IdempotencyRecord
+	now     time.Time
+	crashed bool
+}
+
+func (r *fakeIdempotencyRepo) ClaimKey(ctx context.Context, record *models.IdempotencyRecord, ttl time.Duration, lease time.Duration) (*models.IdempotencyRecord, bool, error) {
+	existing, ok := r.records[record.UserEmail+"/"+record.Key]
+	if ok && !(existing.CompletedAt == nil && existing.Fingerprint == record.Fingerprint && existing.LockedUntil.Before(r.now)) {
+		copied := *existing
+		return &copied, false, nil
+	}
+	lockedUntil := r.now.Add(lease)
+	record.LockedUntil = &lockedUntil
+	r.records[record.UserEmail+"/"+record.Key] = record
+	return record, true, nil
+}
+
+func (r *fakeIdempotencyRepo) CompleteKey(ctx context.Context, userEmail string, key string, statusCode int, contentType string, body []byte) error {
+	record := r.records[userEmail+"/"+key]
+	record.StatusCode = &statusCode
+	record.ContentType = &contentType
+	record.ResponseBody = body
+	record.CompletedAt = &r.now
+	record.LockedUntil = nil
+	return nil
+}
+
+func (r *fakeIdempotencyRepo) ReleaseKey(ctx context.Context, userEmail string, key string) error {
+	if !r.crashed {
+		delete(r.records, userEmail+"/"+key)
+	}
+	return nil
+}
+
+type idempotentInput struct {
+	Body struct {
+		Seats int `json:"seats"`
+	}
+}
+
+type idempotentOutput struct {
+	Body struct {
+		Call int `json:"call"`
+	}
+}
+
+// newIdempotentAPI serves POST /bookings behind Idempotent for a signed in user. The handler counts its
+// calls and fails with a server error while fail is set.
+func newIdempotentAPI(t *testing.T, repo *fakeIdempotencyRepo, calls *int, fail *bool) humatest.TestAPI {
+	_, api := humatest.New(t)
+	mid := &middlewareImpl{
+		api:                api,
+		idempotencyUsecase: usecases.NewIdempotencyUsecase(repo, config.Idempotency{TTL: 24 * time.Hour}, time.Minute),
+	}
+	withEmail := func(ctx huma.Context, next func(huma.Context)) {
+		next(huma.WithValue(ctx, "email", "student@example.com"))
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "book",
+		Method:        http.MethodPost,
+		Path:          "/bookings",
+		DefaultStatus: http.StatusCreated,
+		Middlewares:   huma.Middlewares{withEmail, mid.Idempotent},
+	}, func(ctx context.Context, input *idempotentInput) (*idempotentOutput, error) {
+		*calls++
+		if *fail {
+			return nil, huma.Error500InternalServerError("internal server error")
+		}
+		out := &idempotentOutput{}
+		out.Body.Call = *calls
+		return out, nil
+	})
+	return api
+}
+
+func TestIdempotent(t *testing.T) {
+	calls, fail := 0, false
+	api := newIdempotentAPI(t, &fakeIdempotencyRepo{records: map[string]*models.IdempotencyRecord{}}, &calls, &fail)
+	key := IdempotencyKeyHeader + ": booking-1"
+
+	first := api.Post("/bookings", key, map[string]any{"seats": 1})
+	if first.Code != http.StatusCreated {
+		t.Fatalf("first request status = %d, want %d", first.Code, http.StatusCreated)
+	}
+
+	replay := api.Post("/bookings", key, map[string]any{"seats": 1})
+	if replay.Code != http.StatusCreated || replay.Body.String() != first.Body.String() {
+		t.Errorf("replay = %d %q, want %d %q", replay.Code, replay.Body.String(), http.StatusCreated, first.Body.String())
+	}
+	if replay.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Errorf("replay is missing the %s header", IdempotentReplayedHeader)
+	}
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+
+	reused := api.Post("/bookings", key, map[string]any{"seats": 2})
+	if reused.Code != http.StatusUnprocessableEntity {
+		t.Errorf("reused key status = %d, want %d", reused.Code, http.StatusUnprocessableEntity)
+	}
+
+	tooLong := api.Post("/bookings", IdempotencyKeyHeader+": "+strings.Repeat("k", IdempotencyKeyMaxLength+1), map[string]any{"seats": 1})
+	if tooLong.Code != http.StatusBadRequest {
+		t.Errorf("long key status = %d, want %d", tooLong.Code, http.StatusBadRequest)
+	}
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+}
+
+func TestIdempotentServerErrorIsRetried(t *testing.T) {
+	calls, fail := 0, true
+	api := newIdempotentAPI(t, &fakeIdempotencyRepo{records: map[string]*models.IdempotencyRecord{}}, &calls, &fail)
+	key := IdempotencyKeyHeader + ": booking-1"
+
+	if resp := api.Post("/bookings", key, map[string]any{"seats": 1}); resp.Code != http.StatusInternalServerError {
+		t.Fatalf("failed request status = %d, want %d", resp.Code, http.StatusInternalServerError)
+	}
+
+	// The key was released, so the retry is handled instead of replaying the failure
+	fail = false
+	resp := api.Post("/bookings", key, map[string]any{"seats": 1})
+	if resp.Code != http.StatusCreated || resp.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Errorf("retry status = %d replayed = %q, want %d and not replayed", resp.Code, resp.Header().Get(IdempotentReplayedHeader), http.StatusCreated)
+	}
+	if calls != 2 {
+		t.Errorf("handler called %d times, want 2", calls)
+	}
+}
+
+func TestIdempotentCrashedRequestIsTakenOver(t *testing.T) {
+	calls, fail := 0, true
+	repo := &fakeIdempotencyRepo{records: map[string]*models.IdempotencyRecord{}, now: time.Now(), crashed: true}
+	api := newIdempotentAPI(t, repo, &calls, &fail)
+	key := IdempotencyKeyHeader + ": booking-1"
+
+	// The key is never released, it stays in progress until its lease runs out
+	api.Post("/bookings", key, map[string]any{"seats": 1})
+	repo.crashed, fail = false, false
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		seats   int
+		want    int
+	}{
+		{name: "retry within the lease", elapsed: time.Minute, seats: 1, want: http.StatusConflict},
+		{name: "other request after the lease", elapsed: 2 * time.Minute, seats: 2, want: http.StatusUnprocessableEntity},
+		{name: "retry after the lease", elapsed: 2 * time.Minute, seats: 1, want: http.StatusCreated},
+		{name: "replay of the retry", elapsed: 2 * time.Minute, seats: 1, want: http.StatusCreated},
+	}
+
+	start := repo.now
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.now = start.Add(tt.elapsed)
+			if resp := api.Post("/bookings", key, map[string]any{"seats": tt.seats}); resp.Code != tt.want {
+				t.Errorf("status = %d, want %d", resp.Code, tt.want)
+			}
+		})
+	}
+	if calls != 2 {
+		t.Errorf("handler called %d times, want 2", calls)
+	}
+}
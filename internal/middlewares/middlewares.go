@@ -1,30 +1,51 @@
 package middlewares
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
 	"github.com/esc-chula/intania-openhouse-2026-api/pkg/config"
 	"github.com/esc-chula/intania-openhouse-2026-api/pkg/firebaseadapter"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/logger"
 )
 
 // Middleware interface
 type Middleware interface {
 	WithAuthContext(ctx huma.Context, next func(huma.Context))
+	RequireRole(roles ...models.StaffRole) func(ctx huma.Context, next func(huma.Context))
+	Idempotent(ctx huma.Context, next func(huma.Context))
+	RateLimit(route usecases.RateLimitRoute) func(ctx huma.Context, next func(huma.Context))
+	RequestTimeout(ctx huma.Context, next func(huma.Context))
 }
 
 type middlewareImpl struct {
-	cfg             config.Config
-	api             huma.API
-	firebaseAdapter firebaseadapter.FirebaseAdapter
+	cfg                config.Config
+	api                huma.API
+	firebaseAdapter    firebaseadapter.FirebaseAdapter
+	staffUsecase       usecases.StaffUsecase
+	idempotencyUsecase usecases.IdempotencyUsecase
+	rateLimitUsecase   usecases.RateLimitUsecase
 }
 
-func NewMiddleware(cfg config.Config, api huma.API, firebaseAdapter firebaseadapter.FirebaseAdapter) Middleware {
+func NewMiddleware(
+	cfg config.Config,
+	api huma.API,
+	firebaseAdapter firebaseadapter.FirebaseAdapter,
+	staffUsecase usecases.StaffUsecase,
+	idempotencyUsecase usecases.IdempotencyUsecase,
+	rateLimitUsecase usecases.RateLimitUsecase,
+) Middleware {
 	return &middlewareImpl{
-		cfg:             cfg,
-		api:             api,
-		firebaseAdapter: firebaseAdapter,
+		cfg:                cfg,
+		api:                api,
+		firebaseAdapter:    firebaseAdapter,
+		staffUsecase:       staffUsecase,
+		idempotencyUsecase: idempotencyUsecase,
+		rateLimitUsecase:   rateLimitUsecase,
 	}
 }
 
@@ -48,6 +69,8 @@ func (m *middlewareImpl) WithAuthContext(ctx huma.Context, next func(huma.Contex
 		return
 	}
 
+	logger.SetUser(ctx.Context(), tokenInfo.UserId, tokenInfo.Email)
+
 	ctx = huma.WithValue(ctx, "uid", tokenInfo.UserId)
 	ctx = huma.WithValue(ctx, "email", tokenInfo.Email)
 	ctx = huma.WithValue(ctx, "display_name", tokenInfo.DisplayName)
@@ -55,3 +78,43 @@ func (m *middlewareImpl) WithAuthContext(ctx huma.Context, next func(huma.Contex
 
 	next(ctx)
 }
+
+// RequireRole only lets the request through when the authenticated email holds one of the given roles.
+// It must be applied after WithAuthContext. Resolved roles are cached in the context under "staff_roles",
+// so stacking it on a group and on an operation only hits the database once.
+func (m *middlewareImpl) RequireRole(roles ...models.StaffRole) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		staffRoles, ok := ctx.Context().Value("staff_roles").([]models.StaffRole)
+		if !ok {
+			email, ok := ctx.Context().Value("email").(string)
+			if !ok || email == "" {
+				huma.WriteErr(m.api, ctx, http.StatusUnauthorized, "email not found in context")
+				return
+			}
+
+			resolved, err := m.staffUsecase.GetRoles(ctx.Context(), email)
+			if err != nil {
+				huma.WriteErr(m.api, ctx, http.StatusInternalServerError, "internal server error", err)
+				return
+			}
+			staffRoles = resolved
+			ctx = huma.WithValue(ctx, "staff_roles", staffRoles)
+		}
+
+		if !models.HasAnyStaffRole(staffRoles, roles...) {
+			huma.WriteErr(m.api, ctx, http.StatusForbidden, "insufficient staff role")
+			return
+		}
+
+		next(ctx)
+	}
+}
+
+// HasRole reports whether the roles resolved by RequireRole contain one of the given roles.
+func HasRole(ctx context.Context, roles ...models.StaffRole) bool {
+	staffRoles, ok := ctx.Value("staff_roles").([]models.StaffRole)
+	if !ok {
+		return false
+	}
+	return models.HasAnyStaffRole(staffRoles, roles...)
+}
@@ -0,0 +1,55 @@
+package middlewares
+
+import (
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
+)
+
+// RateLimit answers 429 with a Retry-After header once the Firebase UID or the client IP used up the
+// tokens of route. It must be applied after WithAuthContext. When the buckets cannot be read the request
+// is let through, the limits protect the database and should not take the endpoints down with it.
+func (m *middlewareImpl) RateLimit(route usecases.RateLimitRoute) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		uid, _ := ctx.Context().Value("uid").(string)
+
+		wait, err := m.rateLimitUsecase.Allow(ctx.Context(), route, uid, m.clientIP(ctx))
+		if err != nil {
+			slog.ErrorContext(ctx.Context(), "rate limit: take token", "route", route, "error", err)
+			next(ctx)
+			return
+		}
+		if wait > 0 {
+			ctx.SetHeader("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			huma.WriteErr(m.api, ctx, http.StatusTooManyRequests, "too many requests, retry later")
+			return
+		}
+
+		next(ctx)
+	}
+}
+
+// clientIP returns, behind trusted proxies, the X-Forwarded-For entry appended by the outermost one, that is
+// ProxyHops entries from the end. Earlier entries are set by the client.
+func (m *middlewareImpl) clientIP(ctx huma.Context) string {
+	if cfg := m.cfg.RateLimit(); cfg.TrustProxy {
+		if forwarded := ctx.Header("X-Forwarded-For"); forwarded != "" {
+			entries := strings.Split(forwarded, ",")
+			// Fewer entries than hops, e.g. a request from inside the network, fall back to the first entry
+			i := max(len(entries)-cfg.ProxyHops, 0)
+			return strings.TrimSpace(entries[i])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(ctx.RemoteAddr())
+	if err != nil {
+		return ctx.RemoteAddr()
+	}
+	return host
+}
@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humachi"
+)
+
+// StreamingMetadataKey marks, in the operation metadata, the operations that stream their response for
+// longer than a regular request, such as Server-Sent Events.
+const StreamingMetadataKey = "streaming"
+
+// RequestTimeout gives every operation the context deadline of App.RequestTimeout. Streaming operations get
+// no deadline and their write deadline is lifted so the WriteTimeout of the server does not cut them off.
+func (m *middlewareImpl) RequestTimeout(ctx huma.Context, next func(huma.Context)) {
+	if streaming, _ := ctx.Operation().Metadata[StreamingMetadataKey].(bool); streaming {
+		_, w := humachi.Unwrap(ctx)
+		if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+			slog.ErrorContext(ctx.Context(), "request timeout: lift write deadline", "error", err)
+		}
+		next(ctx)
+		return
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx.Context(), m.cfg.App().RequestTimeout)
+	defer cancel()
+	next(huma.WithContext(ctx, timeoutCtx))
+}
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE waitlist_entries (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    workshop_id BIGINT NOT NULL REFERENCES workshops(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE (user_id, workshop_id)
+);
+-- Queue order lookup when promoting the head of a workshop's waitlist
+CREATE INDEX idx_waitlist_entries_queue ON waitlist_entries(workshop_id, created_at, id);
+CREATE INDEX idx_waitlist_entries_user_id ON waitlist_entries(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS waitlist_entries;
+-- +goose StatementEnd
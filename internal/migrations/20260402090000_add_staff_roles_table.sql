@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE staff_role AS ENUM ('admin', 'workshop_host', 'booth_operator', 'registration_desk');
+
+-- Roles are keyed by Google account email, so staff do not need to register as attendees
+CREATE TABLE staff_roles (
+    id BIGSERIAL PRIMARY KEY,
+    email TEXT NOT NULL,
+    role staff_role NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE (email, role)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS staff_roles;
+DROP TYPE IF EXISTS staff_role;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Archived rows are hidden from attendees and check-in but kept so existing check-ins and stamps stay valid
+ALTER TABLE booths ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE activities ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP WITH TIME ZONE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE activities DROP COLUMN IF EXISTS archived_at;
+ALTER TABLE booths DROP COLUMN IF EXISTS archived_at;
+-- +goose StatementEnd
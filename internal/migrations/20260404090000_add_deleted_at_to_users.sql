@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Deleted accounts are anonymised in place so bookings, check-ins and stamps keep their aggregates
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Audit trail of booking status changes made by background jobs (e.g. the no-show sweeper)
+CREATE TABLE IF NOT EXISTS booking_status_changes (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    from_status booking_status NOT NULL,
+    to_status booking_status NOT NULL,
+    reason TEXT NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_booking_status_changes_booking_id ON booking_status_changes (booking_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS booking_status_changes;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE workshops
+    ADD COLUMN IF NOT EXISTS booking_opens_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS booking_closes_at TIMESTAMP WITH TIME ZONE,
+    ADD CONSTRAINT workshops_booking_window_check CHECK (booking_opens_at < booking_closes_at);
+
+-- Seats of a workshop with releases are opened in stages, percent is the cumulative share of
+-- total_seats bookable from release_at. Workshops without releases open every seat at once.
+CREATE TABLE IF NOT EXISTS workshop_seat_releases (
+    workshop_id BIGINT NOT NULL REFERENCES workshops(id) ON DELETE CASCADE,
+    release_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    percent INT NOT NULL CHECK (percent BETWEEN 1 AND 100),
+
+    PRIMARY KEY (workshop_id, release_at)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS workshop_seat_releases;
+
+ALTER TABLE workshops
+    DROP CONSTRAINT IF EXISTS workshops_booking_window_check,
+    DROP COLUMN IF EXISTS booking_closes_at,
+    DROP COLUMN IF EXISTS booking_opens_at;
+-- +goose StatementEnd
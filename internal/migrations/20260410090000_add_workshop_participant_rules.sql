@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- A workshop accepts the participant types it has a rule for, reserved_percent of its seats
+-- can only be taken by that type. A workshop without rules accepts every participant type.
+CREATE TABLE IF NOT EXISTS workshop_participant_rules (
+    workshop_id BIGINT NOT NULL REFERENCES workshops(id) ON DELETE CASCADE,
+    participant_type participant_type_enum NOT NULL,
+    reserved_percent INT CHECK (reserved_percent BETWEEN 1 AND 100),
+
+    PRIMARY KEY (workshop_id, participant_type)
+);
+
+-- Same eligibility as before: department workshops for students, Intania and outside students,
+-- club workshops for students only
+INSERT INTO workshop_participant_rules (workshop_id, participant_type)
+SELECT ws.id, pt.participant_type
+FROM workshops AS ws
+CROSS JOIN (VALUES
+    ('student'::participant_type_enum),
+    ('intania'::participant_type_enum),
+    ('outside_student'::participant_type_enum)
+) AS pt (participant_type)
+WHERE ws.category = 'department' OR pt.participant_type = 'student';
+
+-- Quotas count bookings by the participant type the seat was taken as
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS participant_type participant_type_enum;
+
+UPDATE bookings AS bk
+SET participant_type = u.participant_type
+FROM users AS u
+WHERE u.id = bk.user_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE bookings DROP COLUMN IF EXISTS participant_type;
+
+DROP TABLE IF EXISTS workshop_participant_rules;
+-- +goose StatementEnd
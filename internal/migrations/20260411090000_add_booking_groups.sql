@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE group_seat_status AS ENUM ('reserved', 'claimed', 'cancelled');
+
+-- A group booking holds seats a teacher or parent reserved for named companions
+CREATE TABLE IF NOT EXISTS booking_groups (
+    id BIGSERIAL PRIMARY KEY,
+    workshop_id BIGINT NOT NULL REFERENCES workshops(id) ON DELETE CASCADE,
+    leader_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_booking_groups_leader_id ON booking_groups(leader_id);
+CREATE INDEX idx_booking_groups_workshop_id ON booking_groups(workshop_id);
+
+-- A reserved seat counts in workshops.registered_count, claiming it turns it into a booking of the claimer
+CREATE TABLE IF NOT EXISTS group_seats (
+    id BIGSERIAL PRIMARY KEY,
+    group_id BIGINT NOT NULL REFERENCES booking_groups(id) ON DELETE CASCADE,
+    companion_name TEXT NOT NULL,
+    participant_type participant_type_enum NOT NULL,
+    claim_code UUID NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    status group_seat_status NOT NULL DEFAULT 'reserved',
+    booking_id BIGINT REFERENCES bookings(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    claimed_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX idx_group_seats_group_id ON group_seats(group_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS group_seats;
+DROP TABLE IF EXISTS booking_groups;
+DROP TYPE IF EXISTS group_seat_status;
+-- +goose StatementEnd
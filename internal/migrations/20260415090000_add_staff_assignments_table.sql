@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Scopes workshop hosts and booth operators to the workshops and booths they may check attendees into
+CREATE TABLE staff_assignments (
+    id BIGSERIAL PRIMARY KEY,
+    email TEXT NOT NULL,
+    workshop_id BIGINT REFERENCES workshops(id) ON DELETE CASCADE,
+    booth_id BIGINT REFERENCES booths(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK ((workshop_id IS NULL) <> (booth_id IS NULL)),
+    UNIQUE (email, workshop_id),
+    UNIQUE (email, booth_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS staff_assignments;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A request holds its key until locked_until, a retry may take over the key of a request that crashed
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP WITH TIME ZONE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS locked_until;
+-- +goose StatementEnd
@@ -1,6 +1,29 @@
 package migrations
 
-import "embed"
+import (
+	"embed"
+	"io/fs"
+
+	"github.com/pressly/goose/v3"
+)
 
 //go:embed *.sql
 var Migrations embed.FS
+
+// LatestVersion returns the version of the newest embedded migration, the schema version the code expects.
+func LatestVersion() (int64, error) {
+	names, err := fs.Glob(Migrations, "*.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest int64
+	for _, name := range names {
+		version, err := goose.NumericComponent(name)
+		if err != nil {
+			return 0, err
+		}
+		latest = max(latest, version)
+	}
+	return latest, nil
+}
@@ -8,17 +8,33 @@ import (
 
 type Activity struct {
 	bun.BaseModel `bun:"table:activities,alias:act"`
-	ID            int64     `bun:"id,pk,autoincrement"        json:"id"`
-	Title         string    `bun:"title,notnull"              json:"title"`
-	Description   string    `bun:"description,notnull"        json:"description"`
-	StartTime     time.Time `bun:"start_time,notnull"         json:"start_time"`
-	EndTime       time.Time `bun:"end_time,notnull"           json:"end_time"`
-	EventDate     string    `bun:"event_date,notnull"         json:"event_date"` // Date in format `2006-01-02`
-	BuildingName  *string   `bun:"building_name"              json:"building_name,omitempty"`
-	Floor         *string   `bun:"floor"                      json:"floor,omitempty"`
-	RoomName      *string   `bun:"room_name"                  json:"room_name,omitempty"`
-	Image         *string   `bun:"image"                      json:"image,omitempty"`
-	Link          *string   `bun:"link"                       json:"link,omitempty"`
+	ID            int64      `bun:"id,pk,autoincrement"        json:"id"`
+	Title         string     `bun:"title,notnull"              json:"title"`
+	Description   string     `bun:"description,notnull"        json:"description"`
+	StartTime     time.Time  `bun:"start_time,notnull"         json:"start_time"`
+	EndTime       time.Time  `bun:"end_time,notnull"           json:"end_time"`
+	EventDate     string     `bun:"event_date,notnull"         json:"event_date"` // Date in format `2006-01-02`
+	BuildingName  *string    `bun:"building_name"              json:"building_name,omitempty"`
+	Floor         *string    `bun:"floor"                      json:"floor,omitempty"`
+	RoomName      *string    `bun:"room_name"                  json:"room_name,omitempty"`
+	Image         *string    `bun:"image"                      json:"image,omitempty"`
+	Link          *string    `bun:"link"                       json:"link,omitempty"`
+	ArchivedAt    *time.Time `bun:"archived_at"               json:"archived_at,omitempty"`
+}
+
+type ActivityOptional struct {
+	bun.BaseModel `bun:"table:activities,alias:act"`
+	ID            *int64     `bun:"id,pk,autoincrement"        json:"id"`
+	Title         *string    `bun:"title"                      json:"title"`
+	Description   *string    `bun:"description"                json:"description"`
+	StartTime     *time.Time `bun:"start_time"                 json:"start_time"`
+	EndTime       *time.Time `bun:"end_time"                   json:"end_time"`
+	EventDate     *string    `bun:"event_date"                 json:"event_date"` // Date in format `2006-01-02`
+	BuildingName  *string    `bun:"building_name"              json:"building_name"`
+	Floor         *string    `bun:"floor"                      json:"floor"`
+	RoomName      *string    `bun:"room_name"                  json:"room_name"`
+	Image         *string    `bun:"image"                      json:"image"`
+	Link          *string    `bun:"link"                       json:"link"`
 }
 
 type ActivityFilter struct {
@@ -27,4 +43,14 @@ type ActivityFilter struct {
 	HappeningNow bool
 	SortBy       string
 	Order        string
+
+	IncludeArchived bool
+}
+
+// ActivitySave is an activity a user starred for their agenda.
+type ActivitySave struct {
+	bun.BaseModel `bun:"table:activity_saves,alias:asv"`
+	UserID        int64     `bun:"user_id,pk"          json:"user_id"`
+	ActivityID    int64     `bun:"activity_id,pk"      json:"activity_id"`
+	CreatedAt     time.Time `bun:"created_at,nullzero" json:"created_at"`
 }
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+type AgendaItemKind string
+
+const (
+	AgendaItemWorkshop AgendaItemKind = "workshop"
+	AgendaItemActivity AgendaItemKind = "activity"
+)
+
+// AgendaItem is a booked workshop or a starred activity of a user.
+type AgendaItem struct {
+	Kind          AgendaItemKind
+	ID            int64 // Workshop or activity ID depending on Kind
+	Title         string
+	Description   string
+	EventDate     string // Date scanned as RFC3339
+	StartTime     time.Time
+	EndTime       time.Time
+	Location      string
+	Link          *string
+	BookingStatus *Status // Only set for workshops
+	HasConflict   bool    // Overlaps another item of the agenda
+}
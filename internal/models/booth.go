@@ -20,6 +20,15 @@ type Booth struct {
 	Name          string        `bun:"name"                   json:"name"`
 	Category      BoothCategory `bun:"category"                   json:"category"`
 	CheckInCode   string        `bun:"check_in_code,nullzero" json:"-"`
+	ArchivedAt    *time.Time    `bun:"archived_at"            json:"archived_at,omitempty"`
+}
+
+type BoothOptional struct {
+	bun.BaseModel `bun:"table:booths,alias:bt"`
+	ID            *int64         `bun:"id,pk,autoincrement"    json:"id"`
+	Name          *string        `bun:"name"                   json:"name"`
+	Category      *BoothCategory `bun:"category"               json:"category"`
+	CheckInCode   *string        `bun:"check_in_code,nullzero" json:"-"`
 }
 
 type BoothCheckIn struct {
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type EventType string
+
+const (
+	EventBookingCreated   EventType = "booking.created"
+	EventBookingCancelled EventType = "booking.cancelled"
+	EventWorkshopAttended EventType = "workshop.attended"
+	EventBoothCheckedIn   EventType = "booth.checked_in"
+	EventStampsRedeemed   EventType = "stamps.redeemed"
+	EventUserRegistered   EventType = "user.registered"
+)
+
+var EventTypes = []EventType{
+	EventBookingCreated,
+	EventBookingCancelled,
+	EventWorkshopAttended,
+	EventBoothCheckedIn,
+	EventStampsRedeemed,
+	EventUserRegistered,
+}
+
+type DomainEvent struct {
+	bun.BaseModel `bun:"table:domain_events,alias:ev"`
+	ID            int64           `bun:"id,pk,autoincrement"      json:"id"`
+	Type          EventType       `bun:"type"                     json:"type"`
+	Payload       json.RawMessage `bun:"payload,type:jsonb"       json:"payload"`
+	OccurredAt    time.Time       `bun:"occurred_at,nullzero"     json:"occurred_at"`
+	DispatchedAt  *time.Time      `bun:"dispatched_at"            json:"-"`
+	Attempts      int             `bun:"attempts"                 json:"-"`
+	NextAttemptAt time.Time       `bun:"next_attempt_at,nullzero" json:"-"`
+	LastError     *string         `bun:"last_error"               json:"-"`
+}
+
+// BookingEventPayload is the payload of booking.created and booking.cancelled.
+type BookingEventPayload struct {
+	BookingID    int64 `json:"booking_id"`
+	UserID       int64 `json:"user_id"`
+	WorkshopID   int64 `json:"workshop_id"`
+	FromWaitlist bool  `json:"from_waitlist,omitempty"`
+}
+
+// CheckInEventPayload is the payload of workshop.attended and booth.checked_in,
+// ID is the workshop or booth ID depending on Type.
+type CheckInEventPayload struct {
+	UserID    int64         `json:"user_id"`
+	Type      string        `json:"type"`
+	ID        int64         `json:"id"`
+	Name      string        `json:"name"`
+	Category  BoothCategory `json:"category"`
+	BookingID int64         `json:"booking_id,omitempty"`
+}
+
+type StampsRedeemedPayload struct {
+	UserID   int64     `json:"user_id"`
+	Category StampType `json:"category"`
+}
+
+type UserRegisteredPayload struct {
+	UserID          int64           `json:"user_id"`
+	ParticipantType ParticipantType `json:"participant_type"`
+}
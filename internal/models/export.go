@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+type ExportKind string
+
+const (
+	ExportKindUsers            ExportKind = "users"
+	ExportKindBookings         ExportKind = "bookings"
+	ExportKindBoothCheckIns    ExportKind = "booth_checkins"
+	ExportKindStampRedemptions ExportKind = "stamp_redemptions"
+)
+
+var ExportKinds = []ExportKind{ExportKindUsers, ExportKindBookings, ExportKindBoothCheckIns, ExportKindStampRedemptions}
+
+// ExportFilter narrows an export. From/To apply to the creation time of the exported row
+// (registration, booking or check-in time) and are ignored for stamp redemptions which carry no timestamp.
+type ExportFilter struct {
+	From            *time.Time
+	To              *time.Time
+	ParticipantType ParticipantType
+}
+
+type BookingExportRow struct {
+	ID               int64            `bun:"id"`
+	Status           Status           `bun:"status"`
+	CreatedAt        time.Time        `bun:"created_at"`
+	CheckedInAt      *time.Time       `bun:"checked_in_at"`
+	UserID           int64            `bun:"user_id"`
+	Email            string           `bun:"email"`
+	FirstName        string           `bun:"first_name"`
+	LastName         string           `bun:"last_name"`
+	ParticipantType  ParticipantType  `bun:"participant_type"`
+	WorkshopID       int64            `bun:"workshop_id"`
+	WorkshopName     string           `bun:"workshop_name"`
+	WorkshopCategory WorkShopCategory `bun:"workshop_category"`
+	EventDate        string           `bun:"event_date"`
+	StartTime        time.Time        `bun:"start_time"`
+	EndTime          time.Time        `bun:"end_time"`
+	Location         string           `bun:"location"`
+}
+
+type BoothCheckInExportRow struct {
+	ID              int64           `bun:"id"`
+	CheckedInAt     time.Time       `bun:"checked_in_at"`
+	UserID          int64           `bun:"user_id"`
+	Email           string          `bun:"email"`
+	FirstName       string          `bun:"first_name"`
+	LastName        string          `bun:"last_name"`
+	ParticipantType ParticipantType `bun:"participant_type"`
+	BoothID         int64           `bun:"booth_id"`
+	BoothName       string          `bun:"booth_name"`
+	BoothCategory   BoothCategory   `bun:"booth_category"`
+}
+
+type StampRedemptionExportRow struct {
+	ID              int64           `bun:"id"`
+	Type            StampType       `bun:"type"`
+	UserID          int64           `bun:"user_id"`
+	Email           string          `bun:"email"`
+	FirstName       string          `bun:"first_name"`
+	LastName        string          `bun:"last_name"`
+	ParticipantType ParticipantType `bun:"participant_type"`
+}
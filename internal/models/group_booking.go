@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type GroupSeatStatus string
+
+const (
+	GroupSeatReserved  GroupSeatStatus = "reserved"
+	GroupSeatClaimed   GroupSeatStatus = "claimed"
+	GroupSeatCancelled GroupSeatStatus = "cancelled"
+)
+
+// BookingGroup holds the seats a leader reserved in a workshop for their companions.
+type BookingGroup struct {
+	bun.BaseModel `bun:"table:booking_groups,alias:bg"`
+	ID            int64     `bun:"id,pk,autoincrement" json:"id"`
+	WorkshopID    int64     `bun:"workshop_id"         json:"workshop_id"`
+	LeaderID      int64     `bun:"leader_id"           json:"leader_id"`
+	CreatedAt     time.Time `bun:"created_at,nullzero" json:"created_at"`
+}
+
+// GroupSeat is one seat of a BookingGroup. A reserved seat counts in the registered count of the workshop,
+// a claimed seat points to the booking of the companion who claimed it.
+type GroupSeat struct {
+	bun.BaseModel   `bun:"table:group_seats,alias:gs"`
+	ID              int64           `bun:"id,pk,autoincrement"       json:"id"`
+	GroupID         int64           `bun:"group_id"                  json:"group_id"`
+	CompanionName   string          `bun:"companion_name"            json:"companion_name"`
+	ParticipantType ParticipantType `bun:"participant_type"          json:"participant_type"`
+	ClaimCode       string          `bun:"claim_code"                json:"claim_code"`
+	Status          GroupSeatStatus `bun:"status"                    json:"status"`
+	BookingID       *int64          `bun:"booking_id"                json:"booking_id"`
+	CreatedAt       time.Time       `bun:"created_at,nullzero"       json:"created_at"`
+	ClaimedAt       *time.Time      `bun:"claimed_at,nullzero"       json:"claimed_at"`
+	CancelledAt     *time.Time      `bun:"cancelled_at,nullzero"     json:"cancelled_at"`
+	BookingStatus   *Status         `bun:"booking_status,scanonly"   json:"booking_status"` // Status of the booking of a claimed seat
+	WorkshopID      int64           `bun:"workshop_id,scanonly"      json:"workshop_id"`    // Only filled by GetSeatByCodeForUpdate and GetSeatForUpdate
+}
+
+// BookingGroupWithWorkshop is used for returning the group bookings of a leader.
+type BookingGroupWithWorkshop struct {
+	ID           int64       `bun:"id"            json:"id"`
+	WorkshopID   int64       `bun:"workshop_id"   json:"workshop_id"`
+	CreatedAt    time.Time   `bun:"created_at"    json:"created_at"`
+	WorkshopName string      `bun:"workshop_name" json:"workshop_name"`
+	EventDate    string      `bun:"event_date"    json:"event_date"`
+	StartTime    time.Time   `bun:"start_time"    json:"start_time"`
+	EndTime      time.Time   `bun:"end_time"      json:"end_time"`
+	Location     string      `bun:"location"      json:"location"`
+	Seats        []GroupSeat `bun:"-"             json:"seats"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// IdempotencyRecord is the first response to a request made with an Idempotency-Key header.
+// The response fields are nil while the first request is still being handled, which holds the key until
+// LockedUntil.
+type IdempotencyRecord struct {
+	bun.BaseModel `bun:"table:idempotency_keys,alias:idk"`
+	UserEmail     string     `bun:"user_email,pk"`
+	Key           string     `bun:"key,pk"`
+	Fingerprint   string     `bun:"fingerprint,notnull"` // Hash of the method, path and body of the first request
+	StatusCode    *int       `bun:"status_code"`
+	ContentType   *string    `bun:"content_type"`
+	ResponseBody  []byte     `bun:"response_body,type:bytea"`
+	CreatedAt     time.Time  `bun:"created_at,nullzero"`
+	CompletedAt   *time.Time `bun:"completed_at"`
+	LockedUntil   *time.Time `bun:"locked_until"` // Lease of the request handling the key, nil once completed
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type NotificationKind string
+
+const (
+	NotificationBookingConfirmed NotificationKind = "booking_confirmed"
+	NotificationBookingCancelled NotificationKind = "booking_cancelled"
+	NotificationWorkshopReminder NotificationKind = "workshop_reminder"
+)
+
+type Notification struct {
+	bun.BaseModel `bun:"table:notification_outbox,alias:nt"`
+	ID            int64            `bun:"id,pk,autoincrement" json:"id"`
+	Kind          NotificationKind `bun:"kind"                json:"kind"`
+	BookingID     int64            `bun:"booking_id"          json:"booking_id"`
+	SendAfter     time.Time        `bun:"send_after,nullzero" json:"send_after"`
+	SentAt        *time.Time       `bun:"sent_at"             json:"sent_at"`
+	Attempts      int              `bun:"attempts"            json:"attempts"`
+	LastError     *string          `bun:"last_error"          json:"last_error"`
+	CreatedAt     time.Time        `bun:"created_at,nullzero" json:"created_at"`
+}
+
+// PendingNotification is a claimed notification with what is needed to render it.
+type PendingNotification struct {
+	ID            int64            `bun:"id"`
+	Kind          NotificationKind `bun:"kind"`
+	Attempts      int              `bun:"attempts"`
+	BookingStatus Status           `bun:"booking_status"`
+	Email         string           `bun:"email"`
+	FirstName     string           `bun:"first_name"`
+	UserDeletedAt *time.Time       `bun:"user_deleted_at"`
+	WorkshopName  string           `bun:"workshop_name"`
+	EventDate     string           `bun:"event_date"`
+	StartTime     time.Time        `bun:"start_time"`
+	EndTime       time.Time        `bun:"end_time"`
+	Location      string           `bun:"location"`
+}
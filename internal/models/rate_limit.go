@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// RateLimitBucket is a token bucket, Tokens is the count at UpdatedAt before the refill since then.
+type RateLimitBucket struct {
+	bun.BaseModel `bun:"table:rate_limit_buckets,alias:rlb"`
+	Key           string     `bun:"key,pk"`
+	Tokens        float64    `bun:"tokens,notnull"`
+	UpdatedAt     time.Time  `bun:"updated_at,nullzero"`
+	LockedUntil   *time.Time `bun:"locked_until"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type StaffRole string
+
+const (
+	StaffRoleAdmin            StaffRole = "admin"
+	StaffRoleWorkshopHost     StaffRole = "workshop_host"
+	StaffRoleBoothOperator    StaffRole = "booth_operator"
+	StaffRoleRegistrationDesk StaffRole = "registration_desk"
+)
+
+var StaffRoles = []StaffRole{
+	StaffRoleAdmin,
+	StaffRoleWorkshopHost,
+	StaffRoleBoothOperator,
+	StaffRoleRegistrationDesk,
+}
+
+type StaffRoleAssignment struct {
+	bun.BaseModel `bun:"table:staff_roles,alias:sr"`
+	ID            int64     `bun:"id,pk,autoincrement"  json:"id"`
+	Email         string    `bun:"email"                json:"email"`
+	Role          StaffRole `bun:"role"                 json:"role"`
+	CreatedAt     time.Time `bun:"created_at,nullzero"  json:"created_at"`
+}
+
+// HasAnyStaffRole reports whether granted contains one of the required roles.
+// Admin implicitly holds every role.
+func HasAnyStaffRole(granted []StaffRole, required ...StaffRole) bool {
+	for _, g := range granted {
+		if g == StaffRoleAdmin {
+			return true
+		}
+		for _, r := range required {
+			if g == r {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// StaffAssignment allows a staff member to check attendees into one workshop or one booth, exactly one
+// of WorkshopID and BoothID is set.
+type StaffAssignment struct {
+	bun.BaseModel `bun:"table:staff_assignments,alias:sa"`
+	ID            int64     `bun:"id,pk,autoincrement" json:"id"`
+	Email         string    `bun:"email"               json:"email"`
+	WorkshopID    *int64    `bun:"workshop_id"         json:"workshop_id,omitempty"`
+	BoothID       *int64    `bun:"booth_id"            json:"booth_id,omitempty"`
+	CreatedAt     time.Time `bun:"created_at,nullzero" json:"created_at"`
+}
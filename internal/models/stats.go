@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+type CountByKey struct {
+	Key   string `bun:"key"   json:"key"`
+	Count int64  `bun:"count" json:"count"`
+}
+
+type WorkshopFill struct {
+	ID              int64   `bun:"id"               json:"id"`
+	Name            string  `bun:"name"             json:"name"`
+	EventDate       string  `bun:"event_date"       json:"event_date"`
+	TotalSeats      int     `bun:"total_seats"      json:"total_seats"`
+	RegisteredCount int     `bun:"registered_count" json:"registered_count"`
+	FillRatio       float64 `bun:"fill_ratio"       json:"fill_ratio"`
+}
+
+type HourlyCount struct {
+	Hour  time.Time `bun:"hour"  json:"hour"`
+	Count int64     `bun:"count" json:"count"`
+}
+
+type StampRedemptionCount struct {
+	Type     StampType `bun:"type"     json:"type"`
+	Redeemed int64     `bun:"redeemed" json:"redeemed"`
+	Total    int64     `bun:"total"    json:"total"`
+}
+
+// AttendanceStats counts bookings of workshops that already ended, Confirmed bookings of an ended workshop count as Absent.
+type AttendanceStats struct {
+	Attended       int64   `json:"attended"`
+	Absent         int64   `json:"absent"`
+	AttendanceRate float64 `json:"attendance_rate"`
+	AbsentRate     float64 `json:"absent_rate"`
+}
+
+type EventStats struct {
+	TotalUsers             int64                  `json:"total_users"`
+	UsersByParticipantType []CountByKey           `json:"users_by_participant_type"`
+	UsersByOriginLocation  []CountByKey           `json:"users_by_origin_location"`
+	UsersByIsFromBangkok   []CountByKey           `json:"users_by_is_from_bangkok"`
+	UsersByTransportMode   []CountByKey           `json:"users_by_transport_mode"`
+	BookingsByStatus       []CountByKey           `json:"bookings_by_status"`
+	WorkshopFill           []WorkshopFill         `json:"workshop_fill"`
+	Attendance             AttendanceStats        `json:"attendance"`
+	BoothCheckInsPerHour   []HourlyCount          `json:"booth_check_ins_per_hour"`
+	StampRedemptionsByType []StampRedemptionCount `json:"stamp_redemptions_by_type"`
+	GeneratedAt            time.Time              `json:"generated_at"`
+}
@@ -43,8 +43,26 @@ type User struct {
 	DiscoveryChannel     []string        `bun:"discovery_channel,array"          json:"discovery_channel"`
 	ExtraAttributes      json.RawMessage `bun:"extra_attributes,type:jsonb"      json:"extra_attributes"`
 
-	CreatedAt time.Time `bun:"created_at,nullzero" json:"created_at"`
-	UpdatedAt time.Time `bun:"updated_at,nullzero" json:"updated_at"`
+	CreatedAt time.Time  `bun:"created_at,nullzero" json:"created_at"`
+	UpdatedAt time.Time  `bun:"updated_at,nullzero" json:"updated_at"`
+	DeletedAt *time.Time `bun:"deleted_at"          json:"deleted_at,omitempty"`
+}
+
+// UserOptional holds the self-service editable fields of a user, nil fields are left unchanged.
+type UserOptional struct {
+	FirstName       *string
+	LastName        *string
+	Gender          *Gender
+	PhoneNumber     *string
+	ParticipantType *ParticipantType
+	TransportMode   *TransportMode
+	IsFromBangkok   *bool
+	OriginLocation  *OriginLocation
+
+	AttendanceDates      []string
+	InterestedActivities []string
+	DiscoveryChannel     []string
+	ExtraAttributes      *ExtraAttributesFields
 }
 
 type StudentExtraAttributes struct {
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type WaitlistEntry struct {
+	bun.BaseModel `bun:"table:waitlist_entries,alias:wl"`
+	ID            int64     `bun:"id,pk,autoincrement"  json:"id"`
+	UserID        int64     `bun:"user_id"              json:"user_id"`
+	WorkshopID    int64     `bun:"workshop_id"          json:"workshop_id"`
+	CreatedAt     time.Time `bun:"created_at,nullzero"  json:"created_at"`
+}
+
+// WaitlistEntryWithWorkshop is used for returning waitlist entries with queue position and workshop info.
+type WaitlistEntryWithWorkshop struct {
+	ID              int64     `bun:"id"               json:"id"`
+	WorkshopID      int64     `bun:"workshop_id"      json:"workshop_id"`
+	Position        int       `bun:"position"         json:"position"`
+	CreatedAt       time.Time `bun:"created_at"       json:"created_at"`
+	WorkshopName    string    `bun:"workshop_name"    json:"workshop_name"`
+	EventDate       string    `bun:"event_date"       json:"event_date"`
+	StartTime       time.Time `bun:"start_time"       json:"start_time"`
+	EndTime         time.Time `bun:"end_time"         json:"end_time"`
+	Location        string    `bun:"location"         json:"location"`
+	Affiliation     string    `bun:"affiliation"      json:"affiliation"`
+	RegisteredCount int       `bun:"registered_count" json:"registered_count"`
+	TotalSeats      int       `bun:"total_seats"      json:"total_seats"`
+}
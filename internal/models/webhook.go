@@ -0,0 +1,61 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type WebhookEndpoint struct {
+	bun.BaseModel `bun:"table:webhook_endpoints,alias:wh"`
+	ID            int64     `bun:"id,pk,autoincrement" json:"id"`
+	URL           string    `bun:"url"                 json:"url"`
+	Secret        string    `bun:"secret"              json:"-"`
+	EventTypes    []string  `bun:"event_types,array"   json:"event_types"`
+	Description   string    `bun:"description"         json:"description"`
+	IsActive      bool      `bun:"is_active"           json:"is_active"`
+	CreatedAt     time.Time `bun:"created_at,nullzero" json:"created_at"`
+	UpdatedAt     time.Time `bun:"updated_at,nullzero" json:"updated_at"`
+}
+
+type WebhookEndpointOptional struct {
+	URL         *string
+	EventTypes  []EventType
+	Description *string
+	IsActive    *bool
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+type WebhookDelivery struct {
+	bun.BaseModel  `bun:"table:webhook_deliveries,alias:whd"`
+	ID             int64                 `bun:"id,pk,autoincrement" json:"id"`
+	EndpointID     int64                 `bun:"endpoint_id"         json:"endpoint_id"`
+	EventID        int64                 `bun:"event_id"            json:"event_id"`
+	EventType      EventType             `bun:"event_type"          json:"event_type"`
+	Payload        json.RawMessage       `bun:"payload,type:jsonb"  json:"payload"`
+	Status         WebhookDeliveryStatus `bun:"status"              json:"status"`
+	Attempts       int                   `bun:"attempts"            json:"attempts"`
+	NextAttemptAt  time.Time             `bun:"next_attempt_at"     json:"next_attempt_at"`
+	LastStatusCode *int                  `bun:"last_status_code"    json:"last_status_code"`
+	LastError      *string               `bun:"last_error"          json:"last_error"`
+	DeliveredAt    *time.Time            `bun:"delivered_at"        json:"delivered_at"`
+	CreatedAt      time.Time             `bun:"created_at,nullzero" json:"created_at"`
+}
+
+// PendingWebhookDelivery is a claimed delivery with the endpoint it goes to.
+type PendingWebhookDelivery struct {
+	ID        int64           `bun:"id"`
+	EventType EventType       `bun:"event_type"`
+	Payload   json.RawMessage `bun:"payload"`
+	Attempts  int             `bun:"attempts"`
+	URL       string          `bun:"url"`
+	Secret    string          `bun:"secret"`
+}
@@ -28,6 +28,11 @@ type Workshop struct {
 	RegisteredCount int              `bun:"registered_count"         json:"registered_count"`
 	Image           string           `bun:"image"                    json:"image"`
 	CheckInCode     string           `bun:"check_in_code,nullzero"   json:"-"`
+	BookingOpensAt  *time.Time       `bun:"booking_opens_at"         json:"booking_opens_at"`
+	BookingClosesAt *time.Time       `bun:"booking_closes_at"        json:"booking_closes_at"`
+
+	// Only filled by ListWorkshop
+	AvailableSeatsNow int `bun:"available_seats_now,scanonly" json:"available_seats_now"`
 }
 
 type WorkshopOptional struct {
@@ -45,6 +50,12 @@ type WorkshopOptional struct {
 	RegisteredCount *int              `bun:"registered_count"         json:"registered_count"`
 	Image           *string           `bun:"image"                    json:"image"`
 	CheckInCode     *string           `bun:"check_in_code,nullzero"   json:"-"`
+	BookingOpensAt  *time.Time        `bun:"booking_opens_at"         json:"booking_opens_at"`
+	BookingClosesAt *time.Time        `bun:"booking_closes_at"        json:"booking_closes_at"`
+
+	// Set by updates to write NULL, which OmitZero cannot tell from a field that is left as is
+	ClearBookingOpensAt  bool `bun:"-" json:"-"`
+	ClearBookingClosesAt bool `bun:"-" json:"-"`
 }
 
 type WorkshopDetail struct {
@@ -54,6 +65,23 @@ type WorkshopDetail struct {
 	Status       *Status `bun:"status"                   json:"status"`
 }
 
+// SeatRelease opens Percent of the seats of a workshop, counted cumulatively, from ReleaseAt.
+type SeatRelease struct {
+	bun.BaseModel `bun:"table:workshop_seat_releases,alias:wsr"`
+	WorkshopID    int64     `bun:"workshop_id,pk" json:"-"`
+	ReleaseAt     time.Time `bun:"release_at,pk"  json:"release_at"`
+	Percent       int       `bun:"percent"        json:"percent"`
+}
+
+// ParticipantRule admits a participant type to a workshop, ReservedPercent of the seats can only be taken by that type.
+// A workshop without rules admits every participant type.
+type ParticipantRule struct {
+	bun.BaseModel   `bun:"table:workshop_participant_rules,alias:wpr"`
+	WorkshopID      int64           `bun:"workshop_id,pk"      json:"-"`
+	ParticipantType ParticipantType `bun:"participant_type,pk" json:"participant_type"`
+	ReservedPercent *int            `bun:"reserved_percent"    json:"reserved_percent"`
+}
+
 type WorkshopFilter struct {
 	Search    string
 	Category  string
@@ -73,30 +101,32 @@ const (
 )
 
 type Booking struct {
-	bun.BaseModel `bun:"table:bookings,alias:bk"`
-	ID            int64      `bun:"id,pk,autoincrement"     json:"id"`
-	UserID        int64      `bun:"user_id"                 json:"user_id"`
-	WorkshopID    int64      `bun:"workshop_id"             json:"workshop_id"`
-	Status        Status     `bun:"status"                  json:"status"`
-	CreatedAt     time.Time  `bun:"created_at,nullzero"     json:"created_at"`
-	CheckedInAt   *time.Time `bun:"checked_in_at,nullzero"  json:"checked_in_at"`
+	bun.BaseModel   `bun:"table:bookings,alias:bk"`
+	ID              int64           `bun:"id,pk,autoincrement"       json:"id"`
+	UserID          int64           `bun:"user_id"                   json:"user_id"`
+	WorkshopID      int64           `bun:"workshop_id"               json:"workshop_id"`
+	Status          Status          `bun:"status"                    json:"status"`
+	CreatedAt       time.Time       `bun:"created_at,nullzero"       json:"created_at"`
+	CheckedInAt     *time.Time      `bun:"checked_in_at,nullzero"    json:"checked_in_at"`
+	ParticipantType ParticipantType `bun:"participant_type,nullzero" json:"participant_type"` // Participant type the seat was taken as
 }
 
 // BookingWithWorkshop is used for returning booking details with workshop info.
 type BookingWithWorkshop struct {
-	ID              int64      `bun:"id"               json:"id"`
-	WorkshopID      int64      `bun:"workshop_id"      json:"workshop_id"`
-	Status          Status     `bun:"status"           json:"status"`
-	CreatedAt       time.Time  `bun:"created_at"       json:"created_at"`
-	CheckedInAt     *time.Time `bun:"checked_in_at"    json:"checked_in_at"`
-	WorkshopName    string     `bun:"workshop_name"    json:"workshop_name"`
-	EventDate       string     `bun:"event_date"       json:"event_date"`
-	StartTime       time.Time  `bun:"start_time"       json:"start_time"`
-	EndTime         time.Time  `bun:"end_time"         json:"end_time"`
-	Location        string     `bun:"location"         json:"location"`
-	Affiliation     string     `bun:"affiliation"      json:"affiliation"`
-	RegisteredCount int        `bun:"registered_count" json:"registered_count"`
-	TotalSeats      int        `bun:"total_seats"      json:"total_seats"`
+	ID               int64            `bun:"id"                json:"id"`
+	WorkshopID       int64            `bun:"workshop_id"       json:"workshop_id"`
+	Status           Status           `bun:"status"            json:"status"`
+	CreatedAt        time.Time        `bun:"created_at"        json:"created_at"`
+	CheckedInAt      *time.Time       `bun:"checked_in_at"     json:"checked_in_at"`
+	WorkshopName     string           `bun:"workshop_name"     json:"workshop_name"`
+	WorkshopCategory WorkShopCategory `bun:"workshop_category" json:"workshop_category"`
+	EventDate        string           `bun:"event_date"        json:"event_date"`
+	StartTime        time.Time        `bun:"start_time"        json:"start_time"`
+	EndTime          time.Time        `bun:"end_time"          json:"end_time"`
+	Location         string           `bun:"location"          json:"location"`
+	Affiliation      string           `bun:"affiliation"       json:"affiliation"`
+	RegisteredCount  int              `bun:"registered_count"  json:"registered_count"`
+	TotalSeats       int              `bun:"total_seats"       json:"total_seats"`
 }
 
 type BookingData struct {
@@ -106,4 +136,18 @@ type BookingData struct {
 	WorkshopID       int64            `bun:"workshop_id"`
 	WorkshopName     string           `bun:"workshop_name"`
 	WorkshopCategory WorkShopCategory `bun:"workshop_category"`
+	UserID           int64            `bun:"user_id"`
+
+	// Only filled by GetBookingDataByID
+	EventDate string    `bun:"event_date"`
+	EndTime   time.Time `bun:"end_time"`
+}
+
+// WorkshopSeatUpdate is published whenever the seats of a workshop change. AvailableSeatsNow also changes
+// when a staged release or the booking window passes, without a write to the workshop.
+type WorkshopSeatUpdate struct {
+	WorkshopID        int64 `bun:"workshop_id"         json:"workshop_id"`
+	RegisteredCount   int   `bun:"registered_count"    json:"registered_count"`
+	TotalSeats        int   `bun:"total_seats"         json:"total_seats"`
+	AvailableSeatsNow int   `bun:"available_seats_now" json:"available_seats_now"`
 }
@@ -8,14 +8,26 @@ import (
 
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
 	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/uptrace/bun"
 )
 
-var ErrActivityNotFound = errors.New("activity not found")
+var (
+	ErrActivityNotFound            = errors.New("activity not found")
+	ErrActivityConstraintViolation = errors.New("activity violates a database constraint")
+)
 
 type ActivityRepo interface {
 	GetActivityByID(ctx context.Context, id int64) (*models.Activity, error)
 	ListActivities(ctx context.Context, filter models.ActivityFilter) ([]*models.Activity, error)
+	GetActivityForUpdate(ctx context.Context, id int64) (*models.Activity, error)
+	CreateActivities(ctx context.Context, activities []*models.Activity) error
+	UpdateActivity(ctx context.Context, id int64, activity *models.ActivityOptional) error
+	ArchiveActivity(ctx context.Context, id int64) error
+	SaveActivity(ctx context.Context, userID int64, activityID int64) error
+	UnsaveActivity(ctx context.Context, userID int64, activityID int64) error
+	GetSavedActivities(ctx context.Context, userID int64) ([]*models.Activity, error)
 }
 
 type activityRepoImpl struct {
@@ -31,7 +43,7 @@ func NewActivityRepo(db *bun.DB) ActivityRepo {
 func (r *activityRepoImpl) GetActivityByID(ctx context.Context, id int64) (*models.Activity, error) {
 	activity := new(models.Activity)
 	err := r.exec.Run(ctx, func(idb bun.IDB) error {
-		return idb.NewSelect().Model(activity).Where("id = ?", id).Scan(ctx)
+		return idb.NewSelect().Model(activity).Where("id = ?", id).Where("archived_at IS NULL").Scan(ctx)
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -47,6 +59,10 @@ func (r *activityRepoImpl) ListActivities(ctx context.Context, filter models.Act
 	err := r.exec.Run(ctx, func(idb bun.IDB) error {
 		query := idb.NewSelect().Model(&activities)
 
+		if !filter.IncludeArchived {
+			query.Where("archived_at IS NULL")
+		}
+
 		if filter.Search != "" {
 			query.Where(
 				"(title ILIKE ? OR description ILIKE ? OR building_name ILIKE ? OR room_name ILIKE ?)",
@@ -88,3 +104,119 @@ func (r *activityRepoImpl) ListActivities(ctx context.Context, filter models.Act
 	}
 	return activities, nil
 }
+
+// GetActivityForUpdate locks the activity row, archived activities are included.
+func (r *activityRepoImpl) GetActivityForUpdate(ctx context.Context, id int64) (*models.Activity, error) {
+	activity := new(models.Activity)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().Model(activity).Where("id = ?", id).For("UPDATE").Scan(ctx)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrActivityNotFound
+		}
+		return nil, err
+	}
+	return activity, nil
+}
+
+func (r *activityRepoImpl) CreateActivities(ctx context.Context, activities []*models.Activity) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewInsert().
+			Model(&activities).
+			ExcludeColumn("id", "archived_at").
+			Returning("*").
+			Exec(ctx)
+		return transformActivityConstraintErr(err)
+	})
+}
+
+// UpdateActivity only writes the non-nil fields of activity.
+func (r *activityRepoImpl) UpdateActivity(ctx context.Context, id int64, activity *models.ActivityOptional) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		result, err := idb.NewUpdate().
+			Model(activity).
+			OmitZero().
+			ExcludeColumn("id").
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return transformActivityConstraintErr(err)
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 0 {
+			return ErrActivityNotFound
+		}
+		return nil
+	})
+}
+
+// ArchiveActivity is idempotent, archiving an archived activity keeps its original archived_at.
+func (r *activityRepoImpl) ArchiveActivity(ctx context.Context, id int64) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		result, err := idb.NewUpdate().
+			Model((*models.Activity)(nil)).
+			Set("archived_at = COALESCE(archived_at, CURRENT_TIMESTAMP)").
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 0 {
+			return ErrActivityNotFound
+		}
+		return nil
+	})
+}
+
+// SaveActivity is idempotent, saving a saved activity keeps its original created_at.
+func (r *activityRepoImpl) SaveActivity(ctx context.Context, userID int64, activityID int64) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewInsert().
+			Model(&models.ActivitySave{UserID: userID, ActivityID: activityID}).
+			On("CONFLICT (user_id, activity_id) DO NOTHING").
+			Exec(ctx)
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return ErrActivityNotFound
+		}
+		return err
+	})
+}
+
+// UnsaveActivity is idempotent.
+func (r *activityRepoImpl) UnsaveActivity(ctx context.Context, userID int64, activityID int64) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewDelete().
+			Model((*models.ActivitySave)(nil)).
+			Where("user_id = ?", userID).
+			Where("activity_id = ?", activityID).
+			Exec(ctx)
+		return err
+	})
+}
+
+// GetSavedActivities returns the activities saved by a user that are not archived.
+func (r *activityRepoImpl) GetSavedActivities(ctx context.Context, userID int64) ([]*models.Activity, error) {
+	activities := make([]*models.Activity, 0)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			Model(&activities).
+			Join("JOIN activity_saves AS asv ON asv.activity_id = act.id").
+			Where("asv.user_id = ?", userID).
+			Where("act.archived_at IS NULL").
+			Scan(ctx)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return activities, nil
+		}
+		return nil, err
+	}
+	return activities, nil
+}
+
+func transformActivityConstraintErr(err error) error {
+	if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.CheckViolation {
+		return ErrActivityConstraintViolation
+	}
+	return err
+}
@@ -23,11 +23,14 @@ var (
 type BookingRepo interface {
 	CreateBooking(ctx context.Context, booking *models.Booking) error
 	GetUserBookings(ctx context.Context, userID int64) ([]models.BookingWithWorkshop, error)
-	CancelBooking(ctx context.Context, userID int64, workshopID int64) error
+	CancelBooking(ctx context.Context, userID int64, workshopID int64) (int64, error)
 	UpdateBookingStatus(ctx context.Context, bookingID int64, status models.Status) error
 	GetBookingData(ctx context.Context, email string, checkInCode string) (models.BookingData, error)
+	GetUserBookingData(ctx context.Context, userID int64, workshopID int64) (models.BookingData, error)
+	GetBookingDataByID(ctx context.Context, bookingID int64) (models.BookingData, error)
 	AttendBooking(ctx context.Context, bookingID int64) error
 	GetAttendedWorkshopsForUser(ctx context.Context, userID int64) ([]models.StampItem, error)
+	MarkNoShowsAbsent(ctx context.Context, reason string, limit int) (int64, error)
 }
 
 type bookingRepoImpl struct {
@@ -53,23 +56,27 @@ func (r *bookingRepoImpl) CreateBooking(ctx context.Context, booking *models.Boo
 	})
 }
 
-func (r *bookingRepoImpl) CancelBooking(ctx context.Context, userID int64, workshopID int64) error {
-	return r.exec.Run(ctx, func(idb bun.IDB) error {
-		result, err := idb.NewUpdate().
+// CancelBooking returns the ID of the cancelled booking.
+func (r *bookingRepoImpl) CancelBooking(ctx context.Context, userID int64, workshopID int64) (int64, error) {
+	var bookingID int64
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		err := idb.NewUpdate().
 			Table("bookings").
 			Set("status = ?", models.StatusCancelled).
 			Where("user_id = ?", userID).
 			Where("workshop_id = ?", workshopID).
 			Where("status = ?", models.StatusConfirmed).
-			Exec(ctx)
+			Returning("id").
+			Scan(ctx, &bookingID)
 		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrBookingNotFound
+			}
 			return err
 		}
-		if n, err := result.RowsAffected(); err == nil && n == 0 {
-			return ErrBookingNotFound
-		}
 		return nil
 	})
+	return bookingID, err
 }
 
 func (r *bookingRepoImpl) GetUserBookings(ctx context.Context, userID int64) ([]models.BookingWithWorkshop, error) {
@@ -83,6 +90,7 @@ func (r *bookingRepoImpl) GetUserBookings(ctx context.Context, userID int64) ([]
 			ColumnExpr("bk.created_at").
 			ColumnExpr("bk.checked_in_at").
 			ColumnExpr("ws.name AS workshop_name").
+			ColumnExpr("ws.category AS workshop_category").
 			ColumnExpr("ws.event_date").
 			ColumnExpr("ws.start_time").
 			ColumnExpr("ws.end_time").
@@ -151,6 +159,7 @@ func (r *bookingRepoImpl) GetBookingData(ctx context.Context, email string, chec
 			TableExpr("bookings AS bk").
 			ColumnExpr("bk.id").
 			ColumnExpr("bk.status").
+			ColumnExpr("bk.user_id").
 			ColumnExpr("bk.workshop_id").
 			ColumnExpr("ws.name AS workshop_name").
 			ColumnExpr("ws.category AS workshop_category").
@@ -173,6 +182,64 @@ func (r *bookingRepoImpl) GetBookingData(ctx context.Context, email string, chec
 	return booking, err
 }
 
+func (r *bookingRepoImpl) GetUserBookingData(ctx context.Context, userID int64, workshopID int64) (models.BookingData, error) {
+	var booking models.BookingData
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		err := idb.NewSelect().
+			TableExpr("bookings AS bk").
+			ColumnExpr("bk.id").
+			ColumnExpr("bk.status").
+			ColumnExpr("bk.user_id").
+			ColumnExpr("bk.workshop_id").
+			ColumnExpr("ws.name AS workshop_name").
+			ColumnExpr("ws.category AS workshop_category").
+			Join("JOIN workshops AS ws ON ws.id = bk.workshop_id").
+			Where("bk.user_id = ?", userID).
+			Where("bk.workshop_id = ?", workshopID).
+			Where("bk.status IN (?)", bun.In([]models.Status{models.StatusConfirmed, models.StatusAttended, models.StatusAbsent})).
+			Scan(ctx, &booking)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrBookingNotFound
+			}
+			return err
+		}
+
+		return nil
+	})
+
+	return booking, err
+}
+
+func (r *bookingRepoImpl) GetBookingDataByID(ctx context.Context, bookingID int64) (models.BookingData, error) {
+	var booking models.BookingData
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		err := idb.NewSelect().
+			TableExpr("bookings AS bk").
+			ColumnExpr("bk.id").
+			ColumnExpr("bk.status").
+			ColumnExpr("bk.user_id").
+			ColumnExpr("bk.workshop_id").
+			ColumnExpr("ws.name AS workshop_name").
+			ColumnExpr("ws.category AS workshop_category").
+			ColumnExpr("ws.event_date").
+			ColumnExpr("ws.end_time").
+			Join("JOIN workshops AS ws ON ws.id = bk.workshop_id").
+			Where("bk.id = ?", bookingID).
+			Scan(ctx, &booking)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrBookingNotFound
+			}
+			return err
+		}
+
+		return nil
+	})
+
+	return booking, err
+}
+
 func (r *bookingRepoImpl) GetAttendedWorkshopsForUser(ctx context.Context, userID int64) ([]models.StampItem, error) {
 	stamps := make([]models.StampItem, 0)
 	err := r.exec.Run(ctx, func(idb bun.IDB) error {
@@ -195,3 +262,37 @@ func (r *bookingRepoImpl) GetAttendedWorkshopsForUser(ctx context.Context, userI
 	}
 	return stamps, nil
 }
+
+// MarkNoShowsAbsent moves up to limit Confirmed bookings of workshops that already ended (in Asia/Bangkok) to Absent,
+// recording each change in booking_status_changes. It returns how many bookings were changed.
+// Bookings locked by a concurrent check-in are skipped and picked up by the next call.
+func (r *bookingRepoImpl) MarkNoShowsAbsent(ctx context.Context, reason string, limit int) (int64, error) {
+	var count int64
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewRaw(`
+			WITH due AS (
+				SELECT bk.id
+				FROM bookings AS bk
+				JOIN workshops AS ws ON ws.id = bk.workshop_id
+				WHERE bk.status = ? AND (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Bangkok') > (ws.event_date + ws.end_time)
+				ORDER BY bk.id
+				LIMIT ?
+				FOR UPDATE OF bk SKIP LOCKED
+			), swept AS (
+				UPDATE bookings AS bk
+				SET status = ?
+				FROM due
+				WHERE bk.id = due.id
+				RETURNING bk.id
+			), audit AS (
+				INSERT INTO booking_status_changes (booking_id, from_status, to_status, reason)
+				SELECT id, ?::booking_status, ?::booking_status, ?
+				FROM swept
+			)
+			SELECT COUNT(*) FROM swept`,
+			models.StatusConfirmed, limit, models.StatusAbsent,
+			models.StatusConfirmed, models.StatusAbsent, reason,
+		).Scan(ctx, &count)
+	})
+	return count, err
+}
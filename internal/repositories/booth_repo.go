@@ -16,12 +16,18 @@ import (
 var (
 	ErrBoothNotFound         = errors.New("booth not found")
 	ErrAlreadyCheckedInBooth = errors.New("already check-in this booth")
+	ErrBoothCheckInCodeTaken = errors.New("booth check-in code already in use")
 )
 
 type BoothRepo interface {
 	GetBoothFromCheckInCode(ctx context.Context, checkInCode string) (*models.Booth, error)
+	GetBoothByID(ctx context.Context, id int64) (*models.Booth, error)
 	CreateBoothCheckIn(ctx context.Context, userID int64, boothID int64) error
 	GetBoothCheckInsForUser(ctx context.Context, userID int64) ([]models.StampItem, error)
+	ListBooths(ctx context.Context, includeArchived bool) ([]*models.Booth, error)
+	CreateBooths(ctx context.Context, booths []*models.Booth) error
+	UpdateBooth(ctx context.Context, id int64, booth *models.BoothOptional) error
+	ArchiveBooth(ctx context.Context, id int64) error
 }
 
 type boothRepoImpl struct {
@@ -41,6 +47,7 @@ func (r *boothRepoImpl) GetBoothFromCheckInCode(ctx context.Context, checkInCode
 			NewSelect().
 			Model((*models.Booth)(nil)).
 			Where("check_in_code = ?", checkInCode).
+			Where("archived_at IS NULL").
 			Scan(ctx, &booth)
 	})
 	if err != nil {
@@ -53,6 +60,21 @@ func (r *boothRepoImpl) GetBoothFromCheckInCode(ctx context.Context, checkInCode
 	return &booth, nil
 }
 
+func (r *boothRepoImpl) GetBoothByID(ctx context.Context, id int64) (*models.Booth, error) {
+	booth := new(models.Booth)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().Model(booth).Where("id = ?", id).Scan(ctx)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrBoothNotFound
+		}
+		return nil, err
+	}
+
+	return booth, nil
+}
+
 func (r *boothRepoImpl) CreateBoothCheckIn(ctx context.Context, userID int64, boothID int64) error {
 	return r.exec.Run(ctx, func(idb bun.IDB) error {
 		_, err := idb.NewInsert().Model(&models.BoothCheckIn{
@@ -93,3 +115,74 @@ func (r *boothRepoImpl) GetBoothCheckInsForUser(ctx context.Context, userID int6
 	}
 	return stamps, nil
 }
+
+func (r *boothRepoImpl) ListBooths(ctx context.Context, includeArchived bool) ([]*models.Booth, error) {
+	booths := make([]*models.Booth, 0)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		query := idb.NewSelect().Model(&booths).Order("category", "name")
+		if !includeArchived {
+			query.Where("archived_at IS NULL")
+		}
+		return query.Scan(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return booths, nil
+}
+
+// CreateBooths inserts all booths in a single statement, booths without a check-in code get one from the database.
+func (r *boothRepoImpl) CreateBooths(ctx context.Context, booths []*models.Booth) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewInsert().
+			Model(&booths).
+			ExcludeColumn("id", "archived_at").
+			Returning("*").
+			Exec(ctx)
+		return transformBoothUniqueErr(err)
+	})
+}
+
+// UpdateBooth only writes the non-nil fields of booth.
+func (r *boothRepoImpl) UpdateBooth(ctx context.Context, id int64, booth *models.BoothOptional) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		result, err := idb.NewUpdate().
+			Model(booth).
+			OmitZero().
+			ExcludeColumn("id").
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return transformBoothUniqueErr(err)
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 0 {
+			return ErrBoothNotFound
+		}
+		return nil
+	})
+}
+
+// ArchiveBooth is idempotent, archiving an archived booth keeps its original archived_at.
+func (r *boothRepoImpl) ArchiveBooth(ctx context.Context, id int64) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		result, err := idb.NewUpdate().
+			Model((*models.Booth)(nil)).
+			Set("archived_at = COALESCE(archived_at, CURRENT_TIMESTAMP)").
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 0 {
+			return ErrBoothNotFound
+		}
+		return nil
+	})
+}
+
+func transformBoothUniqueErr(err error) error {
+	if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+		return ErrBoothCheckInCodeTaken
+	}
+	return err
+}
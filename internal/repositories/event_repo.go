@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/uptrace/bun"
+)
+
+type EventRepo interface {
+	AppendEvent(ctx context.Context, eventType models.EventType, payload any) error
+	ClaimPendingEvents(ctx context.Context, maxAttempts int, lease time.Duration, limit int) ([]models.DomainEvent, error)
+	MarkEventDispatched(ctx context.Context, id int64) error
+	MarkEventFailed(ctx context.Context, id int64, dispatchErr error, retryAt time.Time) error
+}
+
+type eventRepoImpl struct {
+	exec baserepo.Executor
+}
+
+func NewEventRepo(db *bun.DB) EventRepo {
+	return &eventRepoImpl{
+		exec: baserepo.NewExecutor(db),
+	}
+}
+
+// AppendEvent must be called inside the transaction of the state change, so the event is recorded
+// if and only if the change is committed.
+func (r *eventRepoImpl) AppendEvent(ctx context.Context, eventType models.EventType, payload any) error {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewInsert().
+			Model(&models.DomainEvent{
+				Type:    eventType,
+				Payload: raw,
+			}).
+			Exec(ctx)
+		return err
+	})
+}
+
+// ClaimPendingEvents leases due events in order of occurrence by pushing their next attempt back by lease.
+// An event whose dispatcher crashes is claimed again once the lease has expired.
+func (r *eventRepoImpl) ClaimPendingEvents(ctx context.Context, maxAttempts int, lease time.Duration, limit int) ([]models.DomainEvent, error) {
+	events := make([]models.DomainEvent, 0, limit)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		due := idb.NewSelect().
+			Model((*models.DomainEvent)(nil)).
+			Column("id").
+			Where("dispatched_at IS NULL").
+			Where("next_attempt_at <= CURRENT_TIMESTAMP").
+			Where("attempts < ?", maxAttempts).
+			OrderExpr("id").
+			Limit(limit).
+			For("UPDATE SKIP LOCKED")
+
+		return idb.NewRaw(`
+			UPDATE domain_events
+			SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => ?)
+			WHERE id IN (?)
+			RETURNING *`,
+			lease.Seconds(), due,
+		).Scan(ctx, &events)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// RETURNING does not keep the order of the subquery
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	return events, nil
+}
+
+func (r *eventRepoImpl) MarkEventDispatched(ctx context.Context, id int64) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewUpdate().
+			Model((*models.DomainEvent)(nil)).
+			Set("dispatched_at = CURRENT_TIMESTAMP").
+			Set("last_error = NULL").
+			Where("id = ?", id).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (r *eventRepoImpl) MarkEventFailed(ctx context.Context, id int64, dispatchErr error, retryAt time.Time) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewUpdate().
+			Model((*models.DomainEvent)(nil)).
+			Set("attempts = attempts + 1").
+			Set("last_error = ?", dispatchErr.Error()).
+			Set("next_attempt_at = ?", retryAt).
+			Where("id = ?", id).
+			Exec(ctx)
+		return err
+	})
+}
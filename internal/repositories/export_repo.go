@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/uptrace/bun"
+)
+
+// ExportRepo reads rows in pages ordered by id, pass the last id of a page as afterID to get the next one.
+type ExportRepo interface {
+	ListUsers(ctx context.Context, filter models.ExportFilter, afterID int64, limit int) ([]models.User, error)
+	ListBookings(ctx context.Context, filter models.ExportFilter, afterID int64, limit int) ([]models.BookingExportRow, error)
+	ListBoothCheckIns(ctx context.Context, filter models.ExportFilter, afterID int64, limit int) ([]models.BoothCheckInExportRow, error)
+	ListStampRedemptions(ctx context.Context, filter models.ExportFilter, afterID int64, limit int) ([]models.StampRedemptionExportRow, error)
+}
+
+type exportRepoImpl struct {
+	exec baserepo.Executor
+}
+
+func NewExportRepo(db *bun.DB) ExportRepo {
+	return &exportRepoImpl{
+		exec: baserepo.NewExecutor(db),
+	}
+}
+
+func (r *exportRepoImpl) ListUsers(ctx context.Context, filter models.ExportFilter, afterID int64, limit int) ([]models.User, error) {
+	users := make([]models.User, 0, limit)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		query := idb.NewSelect().
+			Model(&users).
+			Where("u.id > ?", afterID).
+			Where("u.deleted_at IS NULL").
+			OrderExpr("u.id").
+			Limit(limit)
+		applyExportFilter(query, filter, "u.created_at")
+		return query.Scan(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *exportRepoImpl) ListBookings(ctx context.Context, filter models.ExportFilter, afterID int64, limit int) ([]models.BookingExportRow, error) {
+	rows := make([]models.BookingExportRow, 0, limit)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		query := idb.NewSelect().
+			TableExpr("bookings AS bk").
+			ColumnExpr("bk.id, bk.created_at, bk.checked_in_at, bk.user_id, bk.workshop_id").
+			ColumnExpr(effectiveBookingStatus+" AS status").
+			ColumnExpr("u.email, u.first_name, u.last_name, u.participant_type").
+			ColumnExpr("ws.name AS workshop_name, ws.category AS workshop_category").
+			ColumnExpr("ws.event_date, ws.start_time, ws.end_time, COALESCE(ws.location, '') AS location").
+			Join("JOIN users AS u ON u.id = bk.user_id").
+			Join("JOIN workshops AS ws ON ws.id = bk.workshop_id").
+			Where("bk.id > ?", afterID).
+			OrderExpr("bk.id").
+			Limit(limit)
+		applyExportFilter(query, filter, "bk.created_at")
+		return query.Scan(ctx, &rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *exportRepoImpl) ListBoothCheckIns(ctx context.Context, filter models.ExportFilter, afterID int64, limit int) ([]models.BoothCheckInExportRow, error) {
+	rows := make([]models.BoothCheckInExportRow, 0, limit)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		query := idb.NewSelect().
+			TableExpr("booth_checkins AS btck").
+			ColumnExpr("btck.id, btck.checked_in_at, btck.user_id, btck.booth_id").
+			ColumnExpr("u.email, u.first_name, u.last_name, u.participant_type").
+			ColumnExpr("bt.name AS booth_name, bt.category AS booth_category").
+			Join("JOIN users AS u ON u.id = btck.user_id").
+			Join("JOIN booths AS bt ON bt.id = btck.booth_id").
+			Where("btck.id > ?", afterID).
+			OrderExpr("btck.id").
+			Limit(limit)
+		applyExportFilter(query, filter, "btck.checked_in_at")
+		return query.Scan(ctx, &rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *exportRepoImpl) ListStampRedemptions(ctx context.Context, filter models.ExportFilter, afterID int64, limit int) ([]models.StampRedemptionExportRow, error) {
+	rows := make([]models.StampRedemptionExportRow, 0, limit)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		query := idb.NewSelect().
+			TableExpr("stamp_posters AS sp").
+			ColumnExpr("sp.id, sp.type, sp.user_id").
+			ColumnExpr("u.email, u.first_name, u.last_name, u.participant_type").
+			Join("JOIN users AS u ON u.id = sp.user_id").
+			Where("sp.is_redeemed").
+			Where("sp.id > ?", afterID).
+			OrderExpr("sp.id").
+			Limit(limit)
+		// stamp posters have no timestamp to filter on
+		applyExportFilter(query, models.ExportFilter{ParticipantType: filter.ParticipantType}, "")
+		return query.Scan(ctx, &rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// applyExportFilter expects the users table to be aliased as u.
+func applyExportFilter(query *bun.SelectQuery, filter models.ExportFilter, timeColumn string) {
+	if filter.From != nil {
+		query.Where("? >= ?", bun.Safe(timeColumn), *filter.From)
+	}
+	if filter.To != nil {
+		query.Where("? < ?", bun.Safe(timeColumn), *filter.To)
+	}
+	if filter.ParticipantType != "" {
+		query.Where("u.participant_type = ?", filter.ParticipantType)
+	}
+}
@@ -0,0 +1,233 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrGroupBookingNotFound = errors.New("group booking not found")
+	ErrGroupSeatNotFound    = errors.New("group seat not found")
+)
+
+type GroupBookingRepo interface {
+	CreateGroup(ctx context.Context, group *models.BookingGroup) error
+	// CreateSeats fills the ID and claim code of every seat.
+	CreateSeats(ctx context.Context, seats []models.GroupSeat) error
+	GetLeaderGroups(ctx context.Context, leaderID int64) ([]models.BookingGroupWithWorkshop, error)
+	GetGroupsSeats(ctx context.Context, groupIDs []int64) ([]models.GroupSeat, error)
+	GetGroupForUpdate(ctx context.Context, groupID int64, leaderID int64) (*models.BookingGroup, error)
+	GetSeatForUpdate(ctx context.Context, groupID int64, seatID int64) (*models.GroupSeat, error)
+	GetSeatByCodeForUpdate(ctx context.Context, claimCode string) (*models.GroupSeat, error)
+	ClaimSeat(ctx context.Context, seatID int64, bookingID int64) error
+	CancelReservedSeats(ctx context.Context, groupID int64, seatIDs []int64) (int, error)
+	// CountLeaderSeats counts the seats a leader holds in a workshop across groups, claimed seats included.
+	CountLeaderSeats(ctx context.Context, leaderID int64, workshopID int64) (int, error)
+}
+
+type groupBookingRepoImpl struct {
+	exec baserepo.Executor
+}
+
+func NewGroupBookingRepo(db *bun.DB) GroupBookingRepo {
+	return &groupBookingRepoImpl{
+		exec: baserepo.NewExecutor(db),
+	}
+}
+
+func (r *groupBookingRepoImpl) CreateGroup(ctx context.Context, group *models.BookingGroup) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewInsert().Model(group).Exec(ctx)
+		return err
+	})
+}
+
+func (r *groupBookingRepoImpl) CreateSeats(ctx context.Context, seats []models.GroupSeat) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewInsert().
+			Model(&seats).
+			ExcludeColumn("id", "claim_code").
+			Returning("id, claim_code").
+			Exec(ctx)
+		return err
+	})
+}
+
+func (r *groupBookingRepoImpl) GetLeaderGroups(ctx context.Context, leaderID int64) ([]models.BookingGroupWithWorkshop, error) {
+	groups := make([]models.BookingGroupWithWorkshop, 0)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			TableExpr("booking_groups AS bg").
+			ColumnExpr("bg.id").
+			ColumnExpr("bg.workshop_id").
+			ColumnExpr("bg.created_at").
+			ColumnExpr("ws.name AS workshop_name").
+			ColumnExpr("ws.event_date").
+			ColumnExpr("ws.start_time").
+			ColumnExpr("ws.end_time").
+			ColumnExpr("ws.location").
+			Join("JOIN workshops AS ws ON ws.id = bg.workshop_id").
+			Where("bg.leader_id = ?", leaderID).
+			OrderExpr("ws.event_date, ws.start_time, bg.id").
+			Scan(ctx, &groups)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return groups, nil
+		}
+		return nil, err
+	}
+	return groups, nil
+}
+
+// GetGroupsSeats returns the seats of the groups ordered by group then seat, with the booking status of claimed seats.
+func (r *groupBookingRepoImpl) GetGroupsSeats(ctx context.Context, groupIDs []int64) ([]models.GroupSeat, error) {
+	seats := make([]models.GroupSeat, 0)
+	if len(groupIDs) == 0 {
+		return seats, nil
+	}
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			Model(&seats).
+			ColumnExpr("gs.*").
+			ColumnExpr("bk.status AS booking_status").
+			Join("LEFT JOIN bookings AS bk ON bk.id = gs.booking_id").
+			Where("gs.group_id IN (?)", bun.In(groupIDs)).
+			OrderExpr("gs.group_id, gs.id").
+			Scan(ctx)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return seats, nil
+		}
+		return nil, err
+	}
+	return seats, nil
+}
+
+func (r *groupBookingRepoImpl) GetGroupForUpdate(ctx context.Context, groupID int64, leaderID int64) (*models.BookingGroup, error) {
+	group := new(models.BookingGroup)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		err := idb.NewSelect().
+			Model(group).
+			Where("id = ?", groupID).
+			Where("leader_id = ?", leaderID).
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrGroupBookingNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+func (r *groupBookingRepoImpl) GetSeatForUpdate(ctx context.Context, groupID int64, seatID int64) (*models.GroupSeat, error) {
+	return r.getSeatForUpdate(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("gs.id = ?", seatID).Where("gs.group_id = ?", groupID)
+	})
+}
+
+func (r *groupBookingRepoImpl) GetSeatByCodeForUpdate(ctx context.Context, claimCode string) (*models.GroupSeat, error) {
+	return r.getSeatForUpdate(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("gs.claim_code = ?", claimCode)
+	})
+}
+
+// getSeatForUpdate locks a seat and fills the workshop ID of its group.
+func (r *groupBookingRepoImpl) getSeatForUpdate(ctx context.Context, where func(*bun.SelectQuery) *bun.SelectQuery) (*models.GroupSeat, error) {
+	seat := new(models.GroupSeat)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		q := idb.NewSelect().
+			Model(seat).
+			ColumnExpr("gs.*").
+			ColumnExpr("bg.workshop_id").
+			Join("JOIN booking_groups AS bg ON bg.id = gs.group_id").
+			For("UPDATE OF gs")
+		err := where(q).Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrGroupSeatNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return seat, nil
+}
+
+func (r *groupBookingRepoImpl) ClaimSeat(ctx context.Context, seatID int64, bookingID int64) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		result, err := idb.NewUpdate().
+			Model((*models.GroupSeat)(nil)).
+			Set("status = ?", models.GroupSeatClaimed).
+			Set("booking_id = ?", bookingID).
+			Set("claimed_at = ?", time.Now()).
+			Where("id = ?", seatID).
+			Where("status = ?", models.GroupSeatReserved). // race safe
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 0 {
+			return ErrGroupSeatNotFound
+		}
+		return nil
+	})
+}
+
+// CancelReservedSeats cancels the given seats of a group that are still reserved, or every reserved seat
+// of the group when seatIDs is empty. It returns how many seats were cancelled.
+func (r *groupBookingRepoImpl) CancelReservedSeats(ctx context.Context, groupID int64, seatIDs []int64) (int, error) {
+	var count int
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		q := idb.NewUpdate().
+			Model((*models.GroupSeat)(nil)).
+			Set("status = ?", models.GroupSeatCancelled).
+			Set("cancelled_at = ?", time.Now()).
+			Where("group_id = ?", groupID).
+			Where("status = ?", models.GroupSeatReserved)
+		if len(seatIDs) > 0 {
+			q = q.Where("id IN (?)", bun.In(seatIDs))
+		}
+		result, err := q.Exec(ctx)
+		if err != nil {
+			return err
+		}
+		n, err := result.RowsAffected()
+		count = int(n)
+		return err
+	})
+	return count, err
+}
+
+func (r *groupBookingRepoImpl) CountLeaderSeats(ctx context.Context, leaderID int64, workshopID int64) (int, error) {
+	var count int
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		var err error
+		count, err = idb.NewSelect().
+			Model((*models.GroupSeat)(nil)).
+			Join("JOIN booking_groups AS bg ON bg.id = gs.group_id").
+			Where("bg.leader_id = ?", leaderID).
+			Where("bg.workshop_id = ?", workshopID).
+			Where("gs.status != ?", models.GroupSeatCancelled).
+			Count(ctx)
+		return err
+	})
+	return count, err
+}
@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/uptrace/bun"
+)
+
+type HealthRepo interface {
+	Ping(ctx context.Context) error
+	// GetMigrationVersion returns the schema version recorded by goose, 0 before any migration.
+	GetMigrationVersion(ctx context.Context) (int64, error)
+}
+
+type healthRepoImpl struct {
+	db   *bun.DB
+	exec baserepo.Executor
+}
+
+func NewHealthRepo(db *bun.DB) HealthRepo {
+	return &healthRepoImpl{
+		db:   db,
+		exec: baserepo.NewExecutor(db),
+	}
+}
+
+func (r *healthRepoImpl) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+func (r *healthRepoImpl) GetMigrationVersion(ctx context.Context) (int64, error) {
+	var version int64
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		// Like goose, the latest row of a version tells whether it is applied, a rolled back
+		// migration leaves an older applied row behind
+		return idb.NewRaw(`
+			SELECT COALESCE(MAX(version_id), 0)
+			FROM (
+				SELECT DISTINCT ON (version_id) version_id, is_applied
+				FROM goose_db_version
+				ORDER BY version_id, id DESC
+			) AS v
+			WHERE is_applied`,
+		).Scan(ctx, &version)
+	})
+	return version, err
+}
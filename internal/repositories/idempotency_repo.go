@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/uptrace/bun"
+)
+
+var ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+
+type IdempotencyRepo interface {
+	// ClaimKey inserts the key leased for lease, or takes over an existing key older than ttl or whose request
+	// with the same fingerprint outlived its lease, and reports whether it did. Otherwise the existing record
+	// is returned.
+	ClaimKey(ctx context.Context, record *models.IdempotencyRecord, ttl time.Duration, lease time.Duration) (*models.IdempotencyRecord, bool, error)
+	CompleteKey(ctx context.Context, userEmail string, key string, statusCode int, contentType string, body []byte) error
+	// ReleaseKey deletes a key whose request has not completed so it can be retried.
+	ReleaseKey(ctx context.Context, userEmail string, key string) error
+	DeleteExpiredKeys(ctx context.Context, ttl time.Duration) (int64, error)
+}
+
+type idempotencyRepoImpl struct {
+	exec baserepo.Executor
+}
+
+func NewIdempotencyRepo(db *bun.DB) IdempotencyRepo {
+	return &idempotencyRepoImpl{
+		exec: baserepo.NewExecutor(db),
+	}
+}
+
+func (r *idempotencyRepoImpl) ClaimKey(ctx context.Context, record *models.IdempotencyRecord, ttl time.Duration, lease time.Duration) (*models.IdempotencyRecord, bool, error) {
+	claimed := true
+	existing := new(models.IdempotencyRecord)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		err := idb.NewInsert().
+			Model(record).
+			Column("user_email", "key", "fingerprint", "locked_until").
+			Value("locked_until", "CURRENT_TIMESTAMP + make_interval(secs => ?)", lease.Seconds()).
+			On("CONFLICT (user_email, key) DO UPDATE").
+			Set("fingerprint = EXCLUDED.fingerprint").
+			Set("status_code = NULL").
+			Set("content_type = NULL").
+			Set("response_body = NULL").
+			Set("created_at = CURRENT_TIMESTAMP").
+			Set("completed_at = NULL").
+			Set("locked_until = EXCLUDED.locked_until").
+			Where("idk.created_at < CURRENT_TIMESTAMP - make_interval(secs => ?)", ttl.Seconds()).
+			// A retry of a request that crashed before completing or releasing the key
+			WhereOr("idk.completed_at IS NULL AND idk.fingerprint = EXCLUDED.fingerprint AND " +
+				"(idk.locked_until IS NULL OR idk.locked_until < CURRENT_TIMESTAMP)").
+			Returning("*").
+			Scan(ctx)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		// The key is held by a request within ttl
+		claimed = false
+		err = idb.NewSelect().
+			Model(existing).
+			Where("user_email = ?", record.UserEmail).
+			Where("key = ?", record.Key).
+			Scan(ctx)
+		if errors.Is(err, sql.ErrNoRows) {
+			// Released by a failed request in between
+			return ErrIdempotencyKeyNotFound
+		}
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if claimed {
+		return record, true, nil
+	}
+	return existing, false, nil
+}
+
+func (r *idempotencyRepoImpl) CompleteKey(ctx context.Context, userEmail string, key string, statusCode int, contentType string, body []byte) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewUpdate().
+			Model((*models.IdempotencyRecord)(nil)).
+			Set("status_code = ?", statusCode).
+			Set("content_type = ?", contentType).
+			Set("response_body = ?", body).
+			Set("completed_at = CURRENT_TIMESTAMP").
+			Set("locked_until = NULL").
+			Where("user_email = ?", userEmail).
+			Where("key = ?", key).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (r *idempotencyRepoImpl) ReleaseKey(ctx context.Context, userEmail string, key string) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewDelete().
+			Model((*models.IdempotencyRecord)(nil)).
+			Where("user_email = ?", userEmail).
+			Where("key = ?", key).
+			Where("completed_at IS NULL").
+			Exec(ctx)
+		return err
+	})
+}
+
+func (r *idempotencyRepoImpl) DeleteExpiredKeys(ctx context.Context, ttl time.Duration) (int64, error) {
+	var n int64
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		result, err := idb.NewDelete().
+			Model((*models.IdempotencyRecord)(nil)).
+			Where("created_at < CURRENT_TIMESTAMP - make_interval(secs => ?)", ttl.Seconds()).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		n, err = result.RowsAffected()
+		return err
+	})
+	return n, err
+}
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/uptrace/bun"
+)
+
+type NotificationRepo interface {
+	EnqueueNotification(ctx context.Context, kind models.NotificationKind, bookingID int64) error
+	EnqueueDueReminders(ctx context.Context, lead time.Duration) (int, error)
+	ClaimPendingNotifications(ctx context.Context, maxAttempts int, lease time.Duration, limit int) ([]models.PendingNotification, error)
+	MarkNotificationSent(ctx context.Context, id int64) error
+	MarkNotificationFailed(ctx context.Context, id int64, sendErr error, retryAt time.Time) error
+}
+
+type notificationRepoImpl struct {
+	exec baserepo.Executor
+}
+
+func NewNotificationRepo(db *bun.DB) NotificationRepo {
+	return &notificationRepoImpl{
+		exec: baserepo.NewExecutor(db),
+	}
+}
+
+// EnqueueNotification should be called inside the transaction that changed the booking.
+func (r *notificationRepoImpl) EnqueueNotification(ctx context.Context, kind models.NotificationKind, bookingID int64) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewInsert().
+			Model(&models.Notification{
+				Kind:      kind,
+				BookingID: bookingID,
+				SendAfter: time.Now(),
+			}).
+			On("CONFLICT (booking_id, kind) DO NOTHING").
+			Exec(ctx)
+		return err
+	})
+}
+
+// EnqueueDueReminders queues a reminder for every confirmed booking whose workshop starts within lead.
+// Workshop times are local to Asia/Bangkok.
+func (r *notificationRepoImpl) EnqueueDueReminders(ctx context.Context, lead time.Duration) (int, error) {
+	var n int64
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		result, err := idb.NewRaw(`
+			INSERT INTO notification_outbox (kind, booking_id)
+			SELECT ?, bk.id
+			FROM bookings AS bk
+			JOIN workshops AS ws ON ws.id = bk.workshop_id
+			WHERE bk.status = ?
+			  AND ws.event_date + ws.start_time > (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Bangkok')
+			  AND ws.event_date + ws.start_time <= (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Bangkok') + make_interval(secs => ?)
+			ON CONFLICT (booking_id, kind) DO NOTHING`,
+			models.NotificationWorkshopReminder, models.StatusConfirmed, lead.Seconds(),
+		).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		n, err = result.RowsAffected()
+		return err
+	})
+	return int(n), err
+}
+
+// ClaimPendingNotifications leases due notifications by pushing their send_after back by lease,
+// a notification whose worker crashes is claimed again once the lease has expired.
+func (r *notificationRepoImpl) ClaimPendingNotifications(ctx context.Context, maxAttempts int, lease time.Duration, limit int) ([]models.PendingNotification, error) {
+	pending := make([]models.PendingNotification, 0, limit)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		due := idb.NewSelect().
+			Model((*models.Notification)(nil)).
+			Column("id").
+			Where("sent_at IS NULL").
+			Where("send_after <= CURRENT_TIMESTAMP").
+			Where("attempts < ?", maxAttempts).
+			OrderExpr("send_after").
+			Limit(limit).
+			For("UPDATE SKIP LOCKED")
+
+		return idb.NewRaw(`
+			UPDATE notification_outbox AS nt
+			SET send_after = CURRENT_TIMESTAMP + make_interval(secs => ?)
+			FROM bookings AS bk
+			JOIN users AS u ON u.id = bk.user_id
+			JOIN workshops AS ws ON ws.id = bk.workshop_id
+			WHERE bk.id = nt.booking_id AND nt.id IN (?)
+			RETURNING nt.id, nt.kind, nt.attempts, bk.status AS booking_status,
+				u.email, u.first_name, u.deleted_at AS user_deleted_at,
+				ws.name AS workshop_name, ws.event_date, ws.start_time, ws.end_time, ws.location`,
+			lease.Seconds(), due,
+		).Scan(ctx, &pending)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return pending, nil
+		}
+		return nil, err
+	}
+	return pending, nil
+}
+
+func (r *notificationRepoImpl) MarkNotificationSent(ctx context.Context, id int64) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewUpdate().
+			Model((*models.Notification)(nil)).
+			Set("sent_at = CURRENT_TIMESTAMP").
+			Where("id = ?", id).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (r *notificationRepoImpl) MarkNotificationFailed(ctx context.Context, id int64, sendErr error, retryAt time.Time) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewUpdate().
+			Model((*models.Notification)(nil)).
+			Set("attempts = attempts + 1").
+			Set("last_error = ?", sendErr.Error()).
+			Set("send_after = ?", retryAt).
+			Where("id = ?", id).
+			Exec(ctx)
+		return err
+	})
+}
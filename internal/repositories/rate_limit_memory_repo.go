@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+)
+
+type memoryRateLimitRepoImpl struct {
+	mu      sync.Mutex
+	buckets map[string]*models.RateLimitBucket
+}
+
+// NewMemoryRateLimitRepo keeps the buckets in process, limits are not shared between instances.
+func NewMemoryRateLimitRepo() RateLimitRepo {
+	return &memoryRateLimitRepoImpl{
+		buckets: make(map[string]*models.RateLimitBucket),
+	}
+}
+
+func (r *memoryRateLimitRepoImpl) TakeToken(ctx context.Context, key string, capacity int, period time.Duration) (time.Duration, error) {
+	rate := float64(capacity) / period.Seconds()
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bucket, ok := r.buckets[key]
+	if !ok {
+		bucket = &models.RateLimitBucket{Key: key, Tokens: float64(capacity), UpdatedAt: now}
+		r.buckets[key] = bucket
+	} else {
+		bucket.Tokens = min(float64(capacity), bucket.Tokens+now.Sub(bucket.UpdatedAt).Seconds()*rate)
+		bucket.UpdatedAt = now
+	}
+
+	if bucket.LockedUntil != nil && bucket.LockedUntil.After(now) {
+		return bucket.LockedUntil.Sub(now), nil
+	}
+	if bucket.Tokens < 1 {
+		return time.Duration((1 - bucket.Tokens) / rate * float64(time.Second)), nil
+	}
+	bucket.Tokens--
+	return 0, nil
+}
+
+func (r *memoryRateLimitRepoImpl) LockKey(ctx context.Context, key string, d time.Duration) error {
+	now := time.Now()
+	until := now.Add(d)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bucket, ok := r.buckets[key]
+	if !ok {
+		bucket = &models.RateLimitBucket{Key: key, UpdatedAt: now}
+		r.buckets[key] = bucket
+	}
+	bucket.LockedUntil = &until
+	return nil
+}
+
+func (r *memoryRateLimitRepoImpl) DeleteIdleBuckets(ctx context.Context, idle time.Duration) (int64, error) {
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var n int64
+	for key, bucket := range r.buckets {
+		if now.Sub(bucket.UpdatedAt) < idle || (bucket.LockedUntil != nil && bucket.LockedUntil.After(now)) {
+			continue
+		}
+		delete(r.buckets, key)
+		n++
+	}
+	return n, nil
+}
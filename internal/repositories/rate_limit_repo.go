@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/uptrace/bun"
+)
+
+// RateLimitRepo stores token buckets, each holding up to capacity tokens refilled evenly over period.
+type RateLimitRepo interface {
+	// TakeToken takes a token from the bucket of key, a missing bucket starts full. It returns zero when the
+	// token was taken, otherwise how long until one is available or the bucket is unlocked.
+	TakeToken(ctx context.Context, key string, capacity int, period time.Duration) (time.Duration, error)
+	// LockKey refuses every token of the bucket of key for d.
+	LockKey(ctx context.Context, key string, d time.Duration) error
+	// DeleteIdleBuckets deletes unlocked buckets untouched for idle, they would be full again by now.
+	DeleteIdleBuckets(ctx context.Context, idle time.Duration) (int64, error)
+}
+
+type rateLimitRepoImpl struct {
+	exec baserepo.Executor
+}
+
+func NewRateLimitRepo(db *bun.DB) RateLimitRepo {
+	return &rateLimitRepoImpl{
+		exec: baserepo.NewExecutor(db),
+	}
+}
+
+func (r *rateLimitRepoImpl) TakeToken(ctx context.Context, key string, capacity int, period time.Duration) (time.Duration, error) {
+	rate := float64(capacity) / period.Seconds()
+
+	var retryAfter time.Duration
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		// Refill first, then take in a single guarded update so concurrent requests cannot overdraw
+		var tokens float64
+		var lockedFor sql.NullFloat64
+		err := idb.NewRaw(`
+			INSERT INTO rate_limit_buckets AS rlb (key, tokens)
+			VALUES (?, ?)
+			ON CONFLICT (key) DO UPDATE
+			SET tokens = LEAST(EXCLUDED.tokens, rlb.tokens + EXTRACT(EPOCH FROM CURRENT_TIMESTAMP - rlb.updated_at) * ?),
+			    updated_at = CURRENT_TIMESTAMP
+			RETURNING tokens, EXTRACT(EPOCH FROM rlb.locked_until - CURRENT_TIMESTAMP)`,
+			key, float64(capacity), rate,
+		).Scan(ctx, &tokens, &lockedFor)
+		if err != nil {
+			return err
+		}
+
+		result, err := idb.NewUpdate().
+			Model((*models.RateLimitBucket)(nil)).
+			Set("tokens = tokens - 1").
+			Where("key = ?", key).
+			Where("tokens >= 1").
+			Where("locked_until IS NULL OR locked_until <= CURRENT_TIMESTAMP").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil || n == 1 {
+			return err
+		}
+
+		if lockedFor.Valid && lockedFor.Float64 > 0 {
+			retryAfter = time.Duration(lockedFor.Float64 * float64(time.Second))
+			return nil
+		}
+		// When another request took the token after the refill, a whole token is an upper bound
+		missing := 1.0
+		if tokens < 1 {
+			missing = 1 - tokens
+		}
+		retryAfter = time.Duration(missing / rate * float64(time.Second))
+		return nil
+	})
+	return retryAfter, err
+}
+
+func (r *rateLimitRepoImpl) LockKey(ctx context.Context, key string, d time.Duration) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewRaw(`
+			INSERT INTO rate_limit_buckets AS rlb (key, tokens, locked_until)
+			VALUES (?, 0, CURRENT_TIMESTAMP + make_interval(secs => ?))
+			ON CONFLICT (key) DO UPDATE
+			SET locked_until = EXCLUDED.locked_until`,
+			key, d.Seconds(),
+		).Exec(ctx)
+		return err
+	})
+}
+
+func (r *rateLimitRepoImpl) DeleteIdleBuckets(ctx context.Context, idle time.Duration) (int64, error) {
+	var n int64
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		result, err := idb.NewDelete().
+			Model((*models.RateLimitBucket)(nil)).
+			Where("updated_at < CURRENT_TIMESTAMP - make_interval(secs => ?)", idle.Seconds()).
+			Where("locked_until IS NULL OR locked_until <= CURRENT_TIMESTAMP").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		n, err = result.RowsAffected()
+		return err
+	})
+	return n, err
+}
@@ -0,0 +1,199 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrStaffRoleAlreadyAssigned = errors.New("staff role already assigned")
+	ErrStaffRoleNotFound        = errors.New("staff role not found")
+	ErrStaffAlreadyAssigned     = errors.New("staff already assigned")
+	ErrStaffAssignmentNotFound  = errors.New("staff assignment not found")
+)
+
+type StaffRepo interface {
+	GetRolesByEmail(ctx context.Context, email string) ([]models.StaffRole, error)
+	ListStaffRoles(ctx context.Context) ([]models.StaffRoleAssignment, error)
+	AssignRole(ctx context.Context, assignment *models.StaffRoleAssignment) error
+	RevokeRole(ctx context.Context, email string, role models.StaffRole) error
+	ListAssignments(ctx context.Context) ([]models.StaffAssignment, error)
+	CreateAssignment(ctx context.Context, assignment *models.StaffAssignment) error
+	DeleteAssignment(ctx context.Context, id int64) error
+	// IsAssigned reports whether email is assigned to the workshop or the booth, exactly one must be set.
+	IsAssigned(ctx context.Context, email string, workshopID *int64, boothID *int64) (bool, error)
+	ListAssignedWorkshopIDs(ctx context.Context, email string) ([]int64, error)
+}
+
+type staffRepoImpl struct {
+	exec baserepo.Executor
+}
+
+func NewStaffRepo(db *bun.DB) StaffRepo {
+	return &staffRepoImpl{
+		exec: baserepo.NewExecutor(db),
+	}
+}
+
+func (r *staffRepoImpl) GetRolesByEmail(ctx context.Context, email string) ([]models.StaffRole, error) {
+	roles := make([]models.StaffRole, 0)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			Model((*models.StaffRoleAssignment)(nil)).
+			Column("role").
+			Where("email = ?", email).
+			Scan(ctx, &roles)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return roles, nil
+		}
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *staffRepoImpl) ListStaffRoles(ctx context.Context) ([]models.StaffRoleAssignment, error) {
+	assignments := make([]models.StaffRoleAssignment, 0)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			Model(&assignments).
+			Order("email ASC", "role ASC").
+			Scan(ctx)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return assignments, nil
+		}
+		return nil, err
+	}
+	return assignments, nil
+}
+
+func (r *staffRepoImpl) AssignRole(ctx context.Context, assignment *models.StaffRoleAssignment) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewInsert().Model(assignment).Returning("id, created_at").Exec(ctx)
+		if err != nil {
+			if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+				return ErrStaffRoleAlreadyAssigned
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *staffRepoImpl) RevokeRole(ctx context.Context, email string, role models.StaffRole) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		result, err := idb.NewDelete().
+			Model((*models.StaffRoleAssignment)(nil)).
+			Where("email = ?", email).
+			Where("role = ?", role).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 0 {
+			return ErrStaffRoleNotFound
+		}
+		return nil
+	})
+}
+
+func (r *staffRepoImpl) ListAssignments(ctx context.Context) ([]models.StaffAssignment, error) {
+	assignments := make([]models.StaffAssignment, 0)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			Model(&assignments).
+			Order("email ASC", "id ASC").
+			Scan(ctx)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return assignments, nil
+		}
+		return nil, err
+	}
+	return assignments, nil
+}
+
+func (r *staffRepoImpl) CreateAssignment(ctx context.Context, assignment *models.StaffAssignment) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewInsert().Model(assignment).Returning("id, created_at").Exec(ctx)
+		if err != nil {
+			if pgErr, ok := err.(*pgconn.PgError); ok {
+				switch pgErr.Code {
+				case pgerrcode.UniqueViolation:
+					return ErrStaffAlreadyAssigned
+				case pgerrcode.ForeignKeyViolation:
+					if assignment.WorkshopID != nil {
+						return ErrWorkshopNotFound
+					}
+					return ErrBoothNotFound
+				}
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *staffRepoImpl) DeleteAssignment(ctx context.Context, id int64) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		result, err := idb.NewDelete().
+			Model((*models.StaffAssignment)(nil)).
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 0 {
+			return ErrStaffAssignmentNotFound
+		}
+		return nil
+	})
+}
+
+func (r *staffRepoImpl) IsAssigned(ctx context.Context, email string, workshopID *int64, boothID *int64) (bool, error) {
+	var assigned bool
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		query := idb.NewSelect().
+			Model((*models.StaffAssignment)(nil)).
+			Where("email = ?", email)
+		if workshopID != nil {
+			query = query.Where("workshop_id = ?", *workshopID)
+		} else {
+			query = query.Where("booth_id = ?", *boothID)
+		}
+		exists, err := query.Exists(ctx)
+		assigned = exists
+		return err
+	})
+	return assigned, err
+}
+
+func (r *staffRepoImpl) ListAssignedWorkshopIDs(ctx context.Context, email string) ([]int64, error) {
+	ids := make([]int64, 0)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			Model((*models.StaffAssignment)(nil)).
+			Column("workshop_id").
+			Where("email = ?", email).
+			Where("workshop_id IS NOT NULL").
+			Scan(ctx, &ids)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ids, nil
+		}
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/uptrace/bun"
+)
+
+// effectiveBookingStatus treats Confirmed bookings of an ended workshop as Absent, same as GetWorkshopDetail.
+// It covers the gap until the sweeper materialises the Absent status.
+const effectiveBookingStatus = `
+	CASE
+		WHEN bk.status = 'Confirmed' AND (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Bangkok') > (ws.event_date + ws.end_time) THEN 'Absent'
+		ELSE bk.status::text
+	END`
+
+type StatsRepo interface {
+	CountUsers(ctx context.Context) (int64, error)
+	CountUsersBy(ctx context.Context, column string) ([]models.CountByKey, error)
+	CountBookingsByStatus(ctx context.Context) ([]models.CountByKey, error)
+	GetWorkshopFill(ctx context.Context) ([]models.WorkshopFill, error)
+	CountBoothCheckInsPerHour(ctx context.Context) ([]models.HourlyCount, error)
+	CountStampRedemptions(ctx context.Context) ([]models.StampRedemptionCount, error)
+}
+
+type statsRepoImpl struct {
+	exec baserepo.Executor
+}
+
+func NewStatsRepo(db *bun.DB) StatsRepo {
+	return &statsRepoImpl{
+		exec: baserepo.NewExecutor(db),
+	}
+}
+
+func (r *statsRepoImpl) CountUsers(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			TableExpr("users").
+			ColumnExpr("COUNT(*)").
+			Scan(ctx, &count)
+	})
+	return count, err
+}
+
+// CountUsersBy groups users by column, column must be a trusted column name of the users table.
+func (r *statsRepoImpl) CountUsersBy(ctx context.Context, column string) ([]models.CountByKey, error) {
+	counts := make([]models.CountByKey, 0)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			TableExpr("users").
+			ColumnExpr("COALESCE(?::text, 'unknown') AS key", bun.Ident(column)).
+			ColumnExpr("COUNT(*) AS count").
+			GroupExpr("1").
+			OrderExpr("count DESC, key").
+			Scan(ctx, &counts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *statsRepoImpl) CountBookingsByStatus(ctx context.Context) ([]models.CountByKey, error) {
+	counts := make([]models.CountByKey, 0)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			TableExpr("bookings AS bk").
+			Join("JOIN workshops AS ws ON ws.id = bk.workshop_id").
+			ColumnExpr(effectiveBookingStatus+" AS key").
+			ColumnExpr("COUNT(*) AS count").
+			GroupExpr("1").
+			OrderExpr("key").
+			Scan(ctx, &counts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *statsRepoImpl) GetWorkshopFill(ctx context.Context) ([]models.WorkshopFill, error) {
+	fills := make([]models.WorkshopFill, 0)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			TableExpr("workshops AS ws").
+			ColumnExpr("ws.id, ws.name, ws.event_date, ws.total_seats, ws.registered_count").
+			ColumnExpr("ws.registered_count::float8 / NULLIF(ws.total_seats, 0) AS fill_ratio").
+			OrderExpr("fill_ratio DESC NULLS LAST, ws.event_date, ws.start_time").
+			Scan(ctx, &fills)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fills, nil
+}
+
+func (r *statsRepoImpl) CountBoothCheckInsPerHour(ctx context.Context) ([]models.HourlyCount, error) {
+	counts := make([]models.HourlyCount, 0)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			TableExpr("booth_checkins").
+			ColumnExpr("date_trunc('hour', checked_in_at) AS hour").
+			ColumnExpr("COUNT(*) AS count").
+			GroupExpr("1").
+			OrderExpr("1").
+			Scan(ctx, &counts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *statsRepoImpl) CountStampRedemptions(ctx context.Context) ([]models.StampRedemptionCount, error) {
+	counts := make([]models.StampRedemptionCount, 0)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			TableExpr("stamp_posters").
+			ColumnExpr("type").
+			ColumnExpr("COUNT(*) FILTER (WHERE is_redeemed) AS redeemed").
+			ColumnExpr("COUNT(*) AS total").
+			GroupExpr("type").
+			OrderExpr("type").
+			Scan(ctx, &counts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
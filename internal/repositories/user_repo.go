@@ -20,6 +20,10 @@ var (
 type UserRepo interface {
 	CreateUser(ctx context.Context, user *models.User) error
 	GetUserByEmail(ctx context.Context, email string, fields []string) (*models.User, error)
+	GetUserByID(ctx context.Context, id int64, fields []string) (*models.User, error)
+	GetUserForUpdate(ctx context.Context, email string) (*models.User, error)
+	UpdateUser(ctx context.Context, user *models.User) error
+	AnonymizeUser(ctx context.Context, id int64) error
 }
 
 type userRepoImpl struct {
@@ -66,3 +70,113 @@ func (r *userRepoImpl) GetUserByEmail(ctx context.Context, email string, fields
 	}
 	return user, nil
 }
+
+func (r *userRepoImpl) GetUserByID(ctx context.Context, id int64, fields []string) (*models.User, error) {
+	user := new(models.User)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		query := idb.NewSelect().
+			Model(user).
+			Where("id = ?", id)
+
+		if len(fields) > 0 {
+			query.Column(fields...)
+		}
+
+		return query.Scan(ctx)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// GetUserForUpdate locks the user row until the surrounding transaction ends.
+func (r *userRepoImpl) GetUserForUpdate(ctx context.Context, email string) (*models.User, error) {
+	user := new(models.User)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			Model(user).
+			Where("email = ?", email).
+			For("UPDATE").
+			Scan(ctx)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// userEditableColumns are the columns a user may change on their own profile.
+var userEditableColumns = []string{
+	"first_name", "last_name", "gender", "phone_number",
+	"participant_type", "transport_mode", "is_from_bangkok", "origin_location",
+	"attendance_dates", "interested_activities", "discovery_channel", "extra_attributes",
+}
+
+func (r *userRepoImpl) UpdateUser(ctx context.Context, user *models.User) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		result, err := idb.NewUpdate().
+			Model(user).
+			Column(userEditableColumns...).
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 0 {
+			return ErrUserNotFound
+		}
+		return nil
+	})
+}
+
+// AnonymizeUser erases the personal data of a user but keeps the row, so that bookings, check-ins
+// and stamps still count in the statistics. The email is replaced to let the person register again.
+// The stored idempotent responses and the companion names of the user's group bookings are erased too.
+// It must be called inside a transaction so nothing is erased when the user is not found.
+func (r *userRepoImpl) AnonymizeUser(ctx context.Context, id int64) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		// Keyed by the email, so this runs before the email is replaced
+		_, err := idb.NewDelete().
+			Model((*models.IdempotencyRecord)(nil)).
+			Where("user_email = (SELECT email FROM users WHERE id = ?)", id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = idb.NewUpdate().
+			Model((*models.GroupSeat)(nil)).
+			Set("companion_name = ''").
+			Where("group_id IN (SELECT id FROM booking_groups WHERE leader_id = ?)", id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		result, err := idb.NewUpdate().
+			Model((*models.User)(nil)).
+			Set("first_name = ''").
+			Set("last_name = ''").
+			Set("phone_number = ''").
+			Set("email = 'deleted-' || id || '@deleted.invalid'").
+			Set("extra_attributes = '{}'::jsonb").
+			Set("deleted_at = CURRENT_TIMESTAMP").
+			Where("id = ?", id).
+			Where("deleted_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 0 {
+			return ErrUserNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrAlreadyOnWaitlist     = errors.New("user already on waitlist")
+	ErrWaitlistEntryNotFound = errors.New("waitlist entry not found")
+)
+
+type WaitlistRepo interface {
+	CreateWaitlistEntry(ctx context.Context, entry *models.WaitlistEntry) error
+	DeleteWaitlistEntry(ctx context.Context, userID int64, workshopID int64) error
+	DeleteUserWaitlistEntries(ctx context.Context, userID int64) error
+	GetWorkshopWaitlist(ctx context.Context, workshopID int64) ([]models.WaitlistEntry, error)
+	GetUserWaitlistEntries(ctx context.Context, userID int64) ([]models.WaitlistEntryWithWorkshop, error)
+}
+
+type waitlistRepoImpl struct {
+	exec baserepo.Executor
+}
+
+func NewWaitlistRepo(db *bun.DB) WaitlistRepo {
+	return &waitlistRepoImpl{
+		exec: baserepo.NewExecutor(db),
+	}
+}
+
+func (r *waitlistRepoImpl) CreateWaitlistEntry(ctx context.Context, entry *models.WaitlistEntry) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewInsert().Model(entry).Exec(ctx)
+		if err != nil {
+			if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+				return ErrAlreadyOnWaitlist
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *waitlistRepoImpl) DeleteWaitlistEntry(ctx context.Context, userID int64, workshopID int64) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		result, err := idb.NewDelete().
+			Model((*models.WaitlistEntry)(nil)).
+			Where("user_id = ?", userID).
+			Where("workshop_id = ?", workshopID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 0 {
+			return ErrWaitlistEntryNotFound
+		}
+		return nil
+	})
+}
+
+func (r *waitlistRepoImpl) DeleteUserWaitlistEntries(ctx context.Context, userID int64) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewDelete().
+			Model((*models.WaitlistEntry)(nil)).
+			Where("user_id = ?", userID).
+			Exec(ctx)
+		return err
+	})
+}
+
+// GetWorkshopWaitlist returns the waitlist of a workshop in queue order.
+// The entries are locked so that concurrent cancellations cannot promote the same user twice.
+func (r *waitlistRepoImpl) GetWorkshopWaitlist(ctx context.Context, workshopID int64) ([]models.WaitlistEntry, error) {
+	entries := make([]models.WaitlistEntry, 0)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			Model(&entries).
+			Where("workshop_id = ?", workshopID).
+			Order("created_at ASC", "id ASC").
+			For("UPDATE").
+			Scan(ctx)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entries, nil
+		}
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *waitlistRepoImpl) GetUserWaitlistEntries(ctx context.Context, userID int64) ([]models.WaitlistEntryWithWorkshop, error) {
+	entries := make([]models.WaitlistEntryWithWorkshop, 0)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		// Rank every entry of the workshops the user is waiting for, then keep only the user's rows
+		ranked := idb.NewSelect().
+			TableExpr("waitlist_entries").
+			ColumnExpr("id, user_id, workshop_id, created_at").
+			ColumnExpr("ROW_NUMBER() OVER (PARTITION BY workshop_id ORDER BY created_at, id) AS position").
+			Where("workshop_id IN (SELECT workshop_id FROM waitlist_entries WHERE user_id = ?)", userID)
+
+		return idb.NewSelect().
+			TableExpr("(?) AS wl", ranked).
+			ColumnExpr("wl.id").
+			ColumnExpr("wl.workshop_id").
+			ColumnExpr("wl.position").
+			ColumnExpr("wl.created_at").
+			ColumnExpr("ws.name AS workshop_name").
+			ColumnExpr("ws.event_date").
+			ColumnExpr("ws.start_time").
+			ColumnExpr("ws.end_time").
+			ColumnExpr("ws.location").
+			ColumnExpr("ws.affiliation").
+			ColumnExpr("ws.registered_count").
+			ColumnExpr("ws.total_seats").
+			Join("JOIN workshops AS ws ON ws.id = wl.workshop_id").
+			Where("wl.user_id = ?", userID).
+			Scan(ctx, &entries)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entries, nil
+		}
+		return nil, err
+	}
+	return entries, nil
+}
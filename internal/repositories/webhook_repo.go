@@ -0,0 +1,249 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrWebhookEndpointNotFound = errors.New("webhook endpoint not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+type WebhookRepo interface {
+	ListEndpoints(ctx context.Context) ([]models.WebhookEndpoint, error)
+	GetEndpointForUpdate(ctx context.Context, id int64) (*models.WebhookEndpoint, error)
+	CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error
+	UpdateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error
+	DeleteEndpoint(ctx context.Context, id int64) error
+
+	EnqueueDeliveries(ctx context.Context, eventID int64, eventType models.EventType, body json.RawMessage) error
+	ClaimPendingDeliveries(ctx context.Context, lease time.Duration, limit int) ([]models.PendingWebhookDelivery, error)
+	MarkDeliverySucceeded(ctx context.Context, id int64, statusCode int) error
+	MarkDeliveryFailed(ctx context.Context, id int64, statusCode *int, deliveryErr error, retryAt *time.Time) error
+	ListDeliveries(ctx context.Context, endpointID int64, status models.WebhookDeliveryStatus, limit int) ([]models.WebhookDelivery, error)
+	ReplayDelivery(ctx context.Context, id int64) error
+}
+
+type webhookRepoImpl struct {
+	exec baserepo.Executor
+}
+
+func NewWebhookRepo(db *bun.DB) WebhookRepo {
+	return &webhookRepoImpl{
+		exec: baserepo.NewExecutor(db),
+	}
+}
+
+func (r *webhookRepoImpl) ListEndpoints(ctx context.Context) ([]models.WebhookEndpoint, error) {
+	endpoints := make([]models.WebhookEndpoint, 0)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			Model(&endpoints).
+			OrderExpr("wh.id").
+			Scan(ctx)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return endpoints, nil
+		}
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+// GetEndpointForUpdate locks the endpoint row until the surrounding transaction ends.
+func (r *webhookRepoImpl) GetEndpointForUpdate(ctx context.Context, id int64) (*models.WebhookEndpoint, error) {
+	endpoint := new(models.WebhookEndpoint)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			Model(endpoint).
+			Where("wh.id = ?", id).
+			For("UPDATE").
+			Scan(ctx)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWebhookEndpointNotFound
+		}
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+func (r *webhookRepoImpl) CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewInsert().
+			Model(endpoint).
+			Returning("*").
+			Exec(ctx)
+		return err
+	})
+}
+
+func (r *webhookRepoImpl) UpdateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		err := idb.NewUpdate().
+			Model(endpoint).
+			Column("url", "event_types", "description", "is_active").
+			WherePK().
+			Returning("updated_at").
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrWebhookEndpointNotFound
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *webhookRepoImpl) DeleteEndpoint(ctx context.Context, id int64) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		result, err := idb.NewDelete().
+			Model((*models.WebhookEndpoint)(nil)).
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 0 {
+			return ErrWebhookEndpointNotFound
+		}
+		return nil
+	})
+}
+
+// EnqueueDeliveries creates a delivery of the event for every active endpoint subscribed to its type.
+// It is idempotent, calling it again for the same event adds nothing.
+func (r *webhookRepoImpl) EnqueueDeliveries(ctx context.Context, eventID int64, eventType models.EventType, body json.RawMessage) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewRaw(`
+			INSERT INTO webhook_deliveries (endpoint_id, event_id, event_type, payload)
+			SELECT wh.id, ?, ?, ?::jsonb
+			FROM webhook_endpoints AS wh
+			WHERE wh.is_active AND ? = ANY(wh.event_types)
+			ON CONFLICT (endpoint_id, event_id) DO NOTHING`,
+			eventID, eventType, string(body), eventType,
+		).Exec(ctx)
+		return err
+	})
+}
+
+// ClaimPendingDeliveries leases due deliveries by pushing their next attempt back by lease,
+// a delivery whose worker crashes is claimed again once the lease has expired.
+func (r *webhookRepoImpl) ClaimPendingDeliveries(ctx context.Context, lease time.Duration, limit int) ([]models.PendingWebhookDelivery, error) {
+	pending := make([]models.PendingWebhookDelivery, 0, limit)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		due := idb.NewSelect().
+			Model((*models.WebhookDelivery)(nil)).
+			Column("id").
+			Where("status = ?", models.WebhookDeliveryPending).
+			Where("next_attempt_at <= CURRENT_TIMESTAMP").
+			OrderExpr("id").
+			Limit(limit).
+			For("UPDATE SKIP LOCKED")
+
+		return idb.NewRaw(`
+			UPDATE webhook_deliveries AS whd
+			SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => ?)
+			FROM webhook_endpoints AS wh
+			WHERE wh.id = whd.endpoint_id AND whd.id IN (?)
+			RETURNING whd.id, whd.event_type, whd.payload, whd.attempts, wh.url, wh.secret`,
+			lease.Seconds(), due,
+		).Scan(ctx, &pending)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return pending, nil
+		}
+		return nil, err
+	}
+	return pending, nil
+}
+
+func (r *webhookRepoImpl) MarkDeliverySucceeded(ctx context.Context, id int64, statusCode int) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewUpdate().
+			Model((*models.WebhookDelivery)(nil)).
+			Set("status = ?", models.WebhookDeliverySucceeded).
+			Set("attempts = attempts + 1").
+			Set("last_status_code = ?", statusCode).
+			Set("last_error = NULL").
+			Set("delivered_at = CURRENT_TIMESTAMP").
+			Where("id = ?", id).
+			Exec(ctx)
+		return err
+	})
+}
+
+// MarkDeliveryFailed schedules another attempt at retryAt, or gives up on the delivery when retryAt is nil.
+func (r *webhookRepoImpl) MarkDeliveryFailed(ctx context.Context, id int64, statusCode *int, deliveryErr error, retryAt *time.Time) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		query := idb.NewUpdate().
+			Model((*models.WebhookDelivery)(nil)).
+			Set("attempts = attempts + 1").
+			Set("last_status_code = ?", statusCode).
+			Set("last_error = ?", deliveryErr.Error()).
+			Where("id = ?", id)
+		if retryAt != nil {
+			query.Set("next_attempt_at = ?", *retryAt)
+		} else {
+			query.Set("status = ?", models.WebhookDeliveryFailed)
+		}
+
+		_, err := query.Exec(ctx)
+		return err
+	})
+}
+
+// ListDeliveries returns the latest deliveries of an endpoint first, status may be empty to list all.
+func (r *webhookRepoImpl) ListDeliveries(ctx context.Context, endpointID int64, status models.WebhookDeliveryStatus, limit int) ([]models.WebhookDelivery, error) {
+	deliveries := make([]models.WebhookDelivery, 0, limit)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		query := idb.NewSelect().
+			Model(&deliveries).
+			Where("whd.endpoint_id = ?", endpointID).
+			OrderExpr("whd.id DESC").
+			Limit(limit)
+		if status != "" {
+			query.Where("whd.status = ?", status)
+		}
+		return query.Scan(ctx)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return deliveries, nil
+		}
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ReplayDelivery sends a delivery again with its original body, whatever its status.
+func (r *webhookRepoImpl) ReplayDelivery(ctx context.Context, id int64) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		result, err := idb.NewUpdate().
+			Model((*models.WebhookDelivery)(nil)).
+			Set("status = ?", models.WebhookDeliveryPending).
+			Set("attempts = 0").
+			Set("next_attempt_at = CURRENT_TIMESTAMP").
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 0 {
+			return ErrWebhookDeliveryNotFound
+		}
+		return nil
+	})
+}
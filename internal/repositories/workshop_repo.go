@@ -3,24 +3,74 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
 	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/uptrace/bun"
 )
 
+// WorkshopSeatsChannel is notified with a JSON models.WorkshopSeatUpdate whenever registered_count, the
+// seats, the booking window or the seat releases of a workshop change.
+// Postgres only delivers notifications of a transaction once it commits.
+const WorkshopSeatsChannel = "workshop_seats"
+
+// bookingOpenExpr is true while the booking window of ws is open.
+const bookingOpenExpr = `
+	(ws.booking_opens_at IS NULL OR ws.booking_opens_at <= CURRENT_TIMESTAMP)
+	AND (ws.booking_closes_at IS NULL OR ws.booking_closes_at > CURRENT_TIMESTAMP)`
+
+// releasedSeatsExpr is how many seats of ws can be booked now: every seat without seat releases,
+// otherwise the share of the latest release that has passed.
+const releasedSeatsExpr = `
+	COALESCE(
+		(
+			SELECT FLOOR(ws.total_seats * MAX(wsr.percent) / 100.0)::int
+			FROM workshop_seat_releases AS wsr
+			WHERE wsr.workshop_id = ws.id AND wsr.release_at <= CURRENT_TIMESTAMP
+		),
+		CASE WHEN EXISTS (SELECT 1 FROM workshop_seat_releases AS wsr WHERE wsr.workshop_id = ws.id) THEN 0 ELSE ws.total_seats END
+	)`
+
+// availableSeatsNowExpr is how many more bookings ws accepts right now.
+const availableSeatsNowExpr = `
+	CASE
+		WHEN ` + bookingOpenExpr + ` THEN GREATEST(LEAST(` + releasedSeatsExpr + `, ws.total_seats) - ws.registered_count, 0)
+		ELSE 0
+	END`
+
+// seatUpdateColumns selects a models.WorkshopSeatUpdate of ws.
+const seatUpdateColumns = "ws.id AS workshop_id, ws.registered_count, ws.total_seats, " +
+	availableSeatsNowExpr + " AS available_seats_now"
+
 var (
-	ErrWorkshopNotFound = errors.New("workshop not found")
-	ErrWorkshopFull     = errors.New("workshop is full")
+	ErrWorkshopNotFound            = errors.New("workshop not found")
+	ErrWorkshopFull                = errors.New("workshop is full")
+	ErrWorkshopConstraintViolation = errors.New("workshop violates a database constraint")
+	ErrSeatsReserved               = errors.New("remaining seats are reserved for other participant types")
 )
 
 type WorkshopRepo interface {
 	GetWorkshopById(ctx context.Context, id int64, fields []string) (*models.WorkshopOptional, error)
 	GetWorkshopDetail(ctx context.Context, userId, workshopId int64, fields []string) (*models.WorkshopDetail, error)
 	ListWorkshop(ctx context.Context, filter models.WorkshopFilter) ([]*models.Workshop, error)
-	IncrementRegisteredCount(ctx context.Context, workshopID int64) error
+	IncrementRegisteredCount(ctx context.Context, workshopID int64, participantType models.ParticipantType, enforceWindow bool) error
 	DecrementRegisteredCount(ctx context.Context, workshopID int64) error
+	GetWorkshopForUpdate(ctx context.Context, id int64) (*models.Workshop, error)
+	// GetWorkshopWithSeats also fills AvailableSeatsNow, as ListWorkshop does.
+	GetWorkshopWithSeats(ctx context.Context, id int64) (*models.Workshop, error)
+	CreateWorkshop(ctx context.Context, workshop *models.Workshop) error
+	UpdateWorkshop(ctx context.Context, id int64, workshop *models.WorkshopOptional) error
+	DeleteWorkshop(ctx context.Context, id int64) error
+	RotateCheckInCode(ctx context.Context, id int64) (string, error)
+	GetReleasedSeats(ctx context.Context, id int64) (int, error)
+	GetSeatReleases(ctx context.Context, workshopID int64) ([]models.SeatRelease, error)
+	ReplaceSeatReleases(ctx context.Context, workshopID int64, releases []models.SeatRelease) error
+	GetParticipantRules(ctx context.Context, workshopID int64) ([]models.ParticipantRule, error)
+	ReplaceParticipantRules(ctx context.Context, workshopID int64, rules []models.ParticipantRule) error
 }
 
 type workshopRepoImpl struct {
@@ -94,7 +144,10 @@ func (r *workshopRepoImpl) GetWorkshopDetail(ctx context.Context, userId, worksh
 func (r *workshopRepoImpl) ListWorkshop(ctx context.Context, filter models.WorkshopFilter) ([]*models.Workshop, error) {
 	workshops := make([]*models.Workshop, 0)
 	err := r.exec.Run(ctx, func(idb bun.IDB) error {
-		query := idb.NewSelect().Model(&workshops)
+		query := idb.NewSelect().
+			Model(&workshops).
+			ColumnExpr("ws.*").
+			ColumnExpr(availableSeatsNowExpr + " AS available_seats_now")
 		if filter.Search != "" {
 			query.Where(
 				"(name ILIKE ? OR description ILIKE ?)",
@@ -125,31 +178,175 @@ func (r *workshopRepoImpl) ListWorkshop(ctx context.Context, filter models.Works
 	return workshops, nil
 }
 
-func (r *workshopRepoImpl) IncrementRegisteredCount(ctx context.Context, workshopID int64) error {
+// seatQuota is read by IncrementRegisteredCount while it holds the lock of the workshop row.
+type seatQuota struct {
+	FreeSeats          int `bun:"free_seats"`
+	UnmetReservedSeats int `bun:"unmet_reserved_seats"`
+}
+
+// IncrementRegisteredCount takes a seat as participantType. It returns ErrSeatsReserved when every free seat
+// is reserved for other participant types, and ErrWorkshopFull when the workshop has no seat that can be
+// booked now, including seats that are not released yet and, when enforceWindow is set, a closed booking window.
+// New bookings enforce the window, promotions from the waitlist do not. Unclaimed group seats count towards the
+// participant type they were reserved as.
+// It must be called inside a transaction: the workshop row stays locked so concurrent bookings see each other.
+func (r *workshopRepoImpl) IncrementRegisteredCount(ctx context.Context, workshopID int64, participantType models.ParticipantType, enforceWindow bool) error {
 	return r.exec.Run(ctx, func(idb bun.IDB) error {
-		result, err := idb.NewUpdate().
-			Table("workshops").
-			Set("registered_count = registered_count + 1").
-			Where("id = ?", workshopID).
-			Where("registered_count < total_seats"). // race safe
-			Exec(ctx)
+		// Lock first, the quota query then runs on a snapshot that includes every booking committed before us
+		_, err := idb.NewRaw("SELECT id FROM workshops WHERE id = ? FOR UPDATE", workshopID).Exec(ctx)
 		if err != nil {
 			return err
 		}
-		if n, err := result.RowsAffected(); err == nil && n == 0 {
-			return ErrWorkshopFull
+
+		var quota seatQuota
+		err = idb.NewRaw(`
+			SELECT
+				ws.total_seats - ws.registered_count AS free_seats,
+				COALESCE(SUM(GREATEST(FLOOR(ws.total_seats * wpr.reserved_percent / 100.0)::int - (
+					SELECT COUNT(*)
+					FROM bookings AS bk
+					WHERE bk.workshop_id = ws.id AND bk.participant_type = wpr.participant_type AND bk.status IN (?)
+				) - (
+					SELECT COUNT(*)
+					FROM group_seats AS gs
+					JOIN booking_groups AS bg ON bg.id = gs.group_id
+					WHERE bg.workshop_id = ws.id AND gs.participant_type = wpr.participant_type AND gs.status = ?
+				), 0)), 0) AS unmet_reserved_seats
+			FROM workshops AS ws
+			LEFT JOIN workshop_participant_rules AS wpr
+				ON wpr.workshop_id = ws.id AND wpr.participant_type != ? AND wpr.reserved_percent IS NOT NULL
+			WHERE ws.id = ?
+			GROUP BY ws.id`,
+			bun.In([]models.Status{models.StatusConfirmed, models.StatusAttended, models.StatusAbsent}),
+			models.GroupSeatReserved, participantType, workshopID,
+		).Scan(ctx, &quota)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrWorkshopNotFound
+			}
+			return err
 		}
-		return nil
+		if quota.FreeSeats > 0 && quota.FreeSeats <= quota.UnmetReservedSeats {
+			return ErrSeatsReserved
+		}
+
+		var seats models.WorkshopSeatUpdate
+		query := idb.NewUpdate().
+			TableExpr("workshops AS ws").
+			Set("registered_count = registered_count + 1").
+			Where("ws.id = ?", workshopID).
+			Where("ws.registered_count < ws.total_seats"). // race safe
+			Where("ws.registered_count < " + releasedSeatsExpr)
+		if enforceWindow {
+			query = query.Where(bookingOpenExpr)
+		}
+		err = query.Returning(seatUpdateColumns).Scan(ctx, &seats)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrWorkshopFull
+			}
+			return err
+		}
+		return notifySeatUpdate(ctx, idb, seats)
 	})
 }
 
 func (r *workshopRepoImpl) DecrementRegisteredCount(ctx context.Context, workshopID int64) error {
 	return r.exec.Run(ctx, func(idb bun.IDB) error {
-		result, err := idb.NewUpdate().
-			Table("workshops").
+		var seats models.WorkshopSeatUpdate
+		err := idb.NewUpdate().
+			TableExpr("workshops AS ws").
 			Set("registered_count = registered_count - 1").
-			Where("id = ?", workshopID).
-			Where("registered_count > 0").
+			Where("ws.id = ?", workshopID).
+			Where("ws.registered_count > 0").
+			Returning(seatUpdateColumns).
+			Scan(ctx, &seats)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrWorkshopNotFound
+			}
+			return err
+		}
+		return notifySeatUpdate(ctx, idb, seats)
+	})
+}
+
+// GetWorkshopForUpdate locks the workshop row until the surrounding transaction ends.
+func (r *workshopRepoImpl) GetWorkshopForUpdate(ctx context.Context, id int64) (*models.Workshop, error) {
+	workshop := new(models.Workshop)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().Model(workshop).Where("id = ?", id).For("UPDATE").Scan(ctx)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWorkshopNotFound
+		}
+		return nil, err
+	}
+	return workshop, nil
+}
+
+func (r *workshopRepoImpl) GetWorkshopWithSeats(ctx context.Context, id int64) (*models.Workshop, error) {
+	workshop := new(models.Workshop)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			Model(workshop).
+			ColumnExpr("ws.*").
+			ColumnExpr(availableSeatsNowExpr+" AS available_seats_now").
+			Where("ws.id = ?", id).
+			Scan(ctx)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWorkshopNotFound
+		}
+		return nil, err
+	}
+	return workshop, nil
+}
+
+func (r *workshopRepoImpl) CreateWorkshop(ctx context.Context, workshop *models.Workshop) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewInsert().
+			Model(workshop).
+			ExcludeColumn("id", "registered_count", "check_in_code").
+			Returning("id, registered_count, check_in_code").
+			Exec(ctx)
+		return transformWorkshopConstraintErr(err)
+	})
+}
+
+// UpdateWorkshop only writes the non-nil fields of workshop, and NULL to the booking window bounds it clears.
+func (r *workshopRepoImpl) UpdateWorkshop(ctx context.Context, id int64, workshop *models.WorkshopOptional) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		query := idb.NewUpdate().
+			Model(workshop).
+			OmitZero().
+			ExcludeColumn("id", "registered_count", "check_in_code").
+			Where("id = ?", id)
+		if workshop.ClearBookingOpensAt {
+			query = query.Value("booking_opens_at", "NULL")
+		}
+		if workshop.ClearBookingClosesAt {
+			query = query.Value("booking_closes_at", "NULL")
+		}
+		result, err := query.Exec(ctx)
+		if err != nil {
+			return transformWorkshopConstraintErr(err)
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 0 {
+			return ErrWorkshopNotFound
+		}
+		// The seats or the booking window may have changed
+		return notifyWorkshopSeats(ctx, idb, id)
+	})
+}
+
+func (r *workshopRepoImpl) DeleteWorkshop(ctx context.Context, id int64) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		result, err := idb.NewDelete().
+			Model((*models.Workshop)(nil)).
+			Where("id = ?", id).
 			Exec(ctx)
 		if err != nil {
 			return err
@@ -160,3 +357,153 @@ func (r *workshopRepoImpl) DecrementRegisteredCount(ctx context.Context, worksho
 		return nil
 	})
 }
+
+func (r *workshopRepoImpl) RotateCheckInCode(ctx context.Context, id int64) (string, error) {
+	var checkInCode string
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewUpdate().
+			Table("workshops").
+			Set("check_in_code = gen_random_uuid()").
+			Where("id = ?", id).
+			Returning("check_in_code").
+			Scan(ctx, &checkInCode)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrWorkshopNotFound
+		}
+		return "", err
+	}
+	return checkInCode, nil
+}
+
+func (r *workshopRepoImpl) GetReleasedSeats(ctx context.Context, id int64) (int, error) {
+	var seats int
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			TableExpr("workshops AS ws").
+			ColumnExpr(releasedSeatsExpr).
+			Where("ws.id = ?", id).
+			Scan(ctx, &seats)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrWorkshopNotFound
+		}
+		return 0, err
+	}
+	return seats, nil
+}
+
+func (r *workshopRepoImpl) GetSeatReleases(ctx context.Context, workshopID int64) ([]models.SeatRelease, error) {
+	releases := make([]models.SeatRelease, 0)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			Model(&releases).
+			Where("wsr.workshop_id = ?", workshopID).
+			OrderExpr("wsr.release_at").
+			Scan(ctx)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return releases, nil
+		}
+		return nil, err
+	}
+	return releases, nil
+}
+
+// ReplaceSeatReleases must be called inside a transaction so the schedule is never seen half replaced.
+func (r *workshopRepoImpl) ReplaceSeatReleases(ctx context.Context, workshopID int64, releases []models.SeatRelease) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewDelete().
+			Model((*models.SeatRelease)(nil)).
+			Where("workshop_id = ?", workshopID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(releases) > 0 {
+			for i := range releases {
+				releases[i].WorkshopID = workshopID
+			}
+			_, err = idb.NewInsert().
+				Model(&releases).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+		}
+		return notifyWorkshopSeats(ctx, idb, workshopID)
+	})
+}
+
+func (r *workshopRepoImpl) GetParticipantRules(ctx context.Context, workshopID int64) ([]models.ParticipantRule, error) {
+	rules := make([]models.ParticipantRule, 0)
+	err := r.exec.Run(ctx, func(idb bun.IDB) error {
+		return idb.NewSelect().
+			Model(&rules).
+			Where("wpr.workshop_id = ?", workshopID).
+			OrderExpr("wpr.participant_type").
+			Scan(ctx)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return rules, nil
+		}
+		return nil, err
+	}
+	return rules, nil
+}
+
+// ReplaceParticipantRules must be called inside a transaction so the rules are never seen half replaced.
+func (r *workshopRepoImpl) ReplaceParticipantRules(ctx context.Context, workshopID int64, rules []models.ParticipantRule) error {
+	return r.exec.Run(ctx, func(idb bun.IDB) error {
+		_, err := idb.NewDelete().
+			Model((*models.ParticipantRule)(nil)).
+			Where("workshop_id = ?", workshopID).
+			Exec(ctx)
+		if err != nil || len(rules) == 0 {
+			return err
+		}
+
+		for i := range rules {
+			rules[i].WorkshopID = workshopID
+		}
+		_, err = idb.NewInsert().
+			Model(&rules).
+			Exec(ctx)
+		return err
+	})
+}
+
+// notifyWorkshopSeats reads the seats of a workshop and notifies them.
+func notifyWorkshopSeats(ctx context.Context, idb bun.IDB, workshopID int64) error {
+	var seats models.WorkshopSeatUpdate
+	err := idb.NewSelect().
+		TableExpr("workshops AS ws").
+		ColumnExpr(seatUpdateColumns).
+		Where("ws.id = ?", workshopID).
+		Scan(ctx, &seats)
+	if err != nil {
+		return err
+	}
+	return notifySeatUpdate(ctx, idb, seats)
+}
+
+func notifySeatUpdate(ctx context.Context, idb bun.IDB, seats models.WorkshopSeatUpdate) error {
+	payload, err := json.Marshal(seats)
+	if err != nil {
+		return err
+	}
+	_, err = idb.NewRaw("SELECT pg_notify(?, ?)", WorkshopSeatsChannel, string(payload)).Exec(ctx)
+	return err
+}
+
+func transformWorkshopConstraintErr(err error) error {
+	if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.CheckViolation {
+		return ErrWorkshopConstraintViolation
+	}
+	return err
+}
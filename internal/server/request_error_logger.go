@@ -3,9 +3,10 @@ package server
 import (
 	"context"
 	"errors"
-	"log"
+	"net/http"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/logger"
 )
 
 type handlerErrorContextKey struct{}
@@ -31,9 +32,12 @@ func ErrorCaptureTransformer(ctx huma.Context, status string, v any) (any, error
 			}
 			recordHandlerError(ctx.Context(), errors.New(errMsg))
 
-			// Sanitize the error model to avoid leaking sensitive internal details
-			em.Detail = "internal server error"
-			em.Errors = nil
+			// Sanitize the error model to avoid leaking sensitive internal details,
+			// 503 details come from the readiness check and are safe to show
+			if em.Status != http.StatusServiceUnavailable {
+				em.Detail = "internal server error"
+				em.Errors = nil
+			}
 		} else {
 			recordHandlerError(ctx.Context(), em)
 		}
@@ -51,12 +55,12 @@ func ErrorRecorderMiddleware(ctx huma.Context, next func(huma.Context)) {
 	next(newCtx)
 }
 
-// ErrorLoggerMiddleware logs the captured errors.
+// ErrorLoggerMiddleware hands the captured error to the record written by RequestLogger.
 // This middleware should be applied after ErrorRecorderMiddleware.
 func ErrorLoggerMiddleware(ctx huma.Context, next func(huma.Context)) {
 	defer func() {
 		if err := getHandlerError(ctx.Context()); err != nil {
-			log.Println("REQUEST ERROR:", err)
+			logger.SetError(ctx.Context(), err)
 		}
 	}()
 	next(ctx)
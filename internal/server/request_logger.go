@@ -0,0 +1,67 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+)
+
+const (
+	requestIDHeader = "X-Request-ID"
+	// maxRequestIDLength bounds request IDs set by clients, longer ones are replaced
+	maxRequestIDLength = 128
+)
+
+// RequestLogger assigns every request an ID, echoed in the X-Request-ID header and attached to every
+// record logged with the request context, and writes one record per request once it is served.
+func RequestLogger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(requestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.NewString()
+		}
+		w.Header().Set(requestIDHeader, requestID)
+
+		ctx, info := logger.WithRequest(r.Context(), requestID)
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		start := time.Now()
+
+		defer func() {
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("route", chi.RouteContext(ctx).RoutePattern()),
+				slog.Int("status", status),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Float64("latency_ms", float64(time.Since(start))/float64(time.Millisecond)),
+				slog.String("remote_addr", r.RemoteAddr),
+			}
+
+			level := slog.LevelInfo
+			if status >= http.StatusBadRequest {
+				err := info.Error()
+				attrs = append(attrs, slog.String("error_category", logger.ErrorCategory(status, err)))
+				if err != nil {
+					attrs = append(attrs, slog.String("error", err.Error()))
+				}
+				level = slog.LevelWarn
+				if status >= http.StatusInternalServerError {
+					level = slog.LevelError
+				}
+			}
+			slog.Default().LogAttrs(ctx, level, "request", attrs...)
+		}()
+
+		next.ServeHTTP(ww, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,25 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/metrics"
+)
+
+// MetricsMiddleware observes the latency of every Huma operation by operation ID.
+func MetricsMiddleware(ctx huma.Context, next func(huma.Context)) {
+	start := time.Now()
+	defer func() {
+		status := ctx.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		metrics.HTTPRequestDuration.
+			WithLabelValues(ctx.Operation().OperationID, ctx.Method(), strconv.Itoa(status)).
+			Observe(time.Since(start).Seconds())
+	}()
+	next(ctx)
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/esc-chula/intania-openhouse-2026-api/internal/server")
+
+// RequestTracer starts the server span of every request, continuing the trace of the caller when it sends a
+// traceparent header. Once chi has routed the request the span is named after the route.
+func RequestTracer(next http.Handler) http.Handler {
+	routed := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+
+		if pattern := chi.RouteContext(r.Context()).RoutePattern(); pattern != "" {
+			trace.SpanFromContext(r.Context()).SetAttributes(semconv.HTTPRoute(pattern))
+		}
+	})
+
+	return otelhttp.NewHandler(routed, "http.server",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			// chi sets the pattern of the request it routes
+			if r.Pattern != "" {
+				return r.Method + " " + r.Pattern
+			}
+			return r.Method
+		}),
+	)
+}
+
+// TracingMiddleware records a span for every Huma operation by operation ID, failed operations are marked
+// as errors when they answer with a server error.
+func TracingMiddleware(ctx huma.Context, next func(huma.Context)) {
+	spanCtx, span := tracer.Start(ctx.Context(), ctx.Operation().OperationID,
+		trace.WithAttributes(attribute.String("request.id", logger.RequestID(ctx.Context()))),
+	)
+	defer func() {
+		status := ctx.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		span.End()
+	}()
+	next(huma.WithContext(ctx, spanCtx))
+}
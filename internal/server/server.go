@@ -2,25 +2,33 @@ package server
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"net/http"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humachi"
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/handlers"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/metrics"
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/middlewares"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/migrations"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/usecases"
 	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
 	"github.com/esc-chula/intania-openhouse-2026-api/pkg/config"
 	"github.com/esc-chula/intania-openhouse-2026-api/pkg/database"
 	"github.com/esc-chula/intania-openhouse-2026-api/pkg/firebaseadapter"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/logger"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/notifier"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/tracing"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	"github.com/uptrace/bun"
-	"github.com/uptrace/bun/extra/bundebug"
 )
 
 func InitServer(cfg config.Config, db *bun.DB) error {
@@ -30,7 +38,8 @@ func InitServer(cfg config.Config, db *bun.DB) error {
 	// Setup request error logger
 	humaCfg.Transformers = append(humaCfg.Transformers, ErrorCaptureTransformer)
 
-	router.Use(middleware.Logger)
+	router.Use(RequestTracer)
+	router.Use(RequestLogger)
 	if cfg.App().IsProduction {
 		humaCfg.DocsPath = ""
 		humaCfg.OpenAPIPath = ""
@@ -50,14 +59,18 @@ func InitServer(cfg config.Config, db *bun.DB) error {
 			http.MethodDelete,
 		},
 		AllowedHeaders:   []string{"*"},
+		ExposedHeaders:   []string{requestIDHeader},
 		AllowCredentials: true,
 	}))
 
 	api := humachi.New(router, humaCfg)
 
+	api.UseMiddleware(TracingMiddleware)
+
 	// Setup request error logger
 	api.UseMiddleware(ErrorRecorderMiddleware)
 	api.UseMiddleware(ErrorLoggerMiddleware)
+	api.UseMiddleware(MetricsMiddleware)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -66,13 +79,9 @@ func InitServer(cfg config.Config, db *bun.DB) error {
 	if db == nil {
 		db = database.NewPostgresDB(cfg.Database())
 	}
-	db.AddQueryHook(bundebug.NewQueryHook(
-		bundebug.WithVerbose(!cfg.App().IsProduction),
-	))
-
-	// Initialize Middleware
-	firebaseAdapter := firebaseadapter.InitFirebaseAuthAdapter(ctx, cfg)
-	mid := middlewares.NewMiddleware(cfg, api, firebaseAdapter)
+	db.AddQueryHook(logger.NewQueryHook(!cfg.App().IsProduction))
+	db.AddQueryHook(tracing.NewQueryHook(!cfg.App().IsProduction))
+	metrics.RegisterDB(db.DB)
 
 	// Create Repositories
 	userRepo := repositories.NewUserRepo(db)
@@ -81,41 +90,194 @@ func InitServer(cfg config.Config, db *bun.DB) error {
 	boothRepo := repositories.NewBoothRepo(db)
 	activityRepo := repositories.NewActivityRepo(db)
 	stampRepo := repositories.NewStampRepo(db)
+	waitlistRepo := repositories.NewWaitlistRepo(db)
+	groupBookingRepo := repositories.NewGroupBookingRepo(db)
+	staffRepo := repositories.NewStaffRepo(db)
+	statsRepo := repositories.NewStatsRepo(db)
+	exportRepo := repositories.NewExportRepo(db)
+	notificationRepo := repositories.NewNotificationRepo(db)
+	eventRepo := repositories.NewEventRepo(db)
+	webhookRepo := repositories.NewWebhookRepo(db)
+	idempotencyRepo := repositories.NewIdempotencyRepo(db)
+	rateLimitRepo := newRateLimitRepo(cfg.RateLimit(), db)
+	healthRepo := repositories.NewHealthRepo(db)
 
 	// Create Transactioner
 	transactioner := baserepo.NewTransactioner(db)
 
 	// Create Usecases
-	userUsecase := usecases.NewUserUsecase(userRepo, stampRepo, transactioner)
-	workshopUsecase := usecases.NewWorkshopUsecase(workshopRepo, userRepo)
-	bookingUsecase := usecases.NewBookingUsecase(bookingRepo, workshopRepo, userRepo, transactioner)
-	checkInUsecase := usecases.NewCheckInUsecase(bookingRepo, boothRepo, userRepo)
-	stampUsecase := usecases.NewStampUsecase(stampRepo, bookingRepo, boothRepo)
-	activityUsecase := usecases.NewActivityUsecase(activityRepo)
+	bookingUsecase := usecases.NewBookingUsecase(bookingRepo, workshopRepo, userRepo, waitlistRepo, groupBookingRepo, notificationRepo, eventRepo, transactioner, cfg.BookingPolicy())
+	userUsecase := usecases.NewUserUsecase(userRepo, stampRepo, eventRepo, bookingUsecase, transactioner)
+	workshopUsecase := usecases.NewWorkshopUsecase(workshopRepo, userRepo, staffRepo, transactioner)
+	checkInUsecase := usecases.NewCheckInUsecase(bookingRepo, boothRepo, userRepo, eventRepo, staffRepo, transactioner, cfg.Token())
+	stampUsecase := usecases.NewStampUsecase(stampRepo, bookingRepo, boothRepo, eventRepo, transactioner)
+	activityUsecase := usecases.NewActivityUsecase(activityRepo, transactioner)
+	boothUsecase := usecases.NewBoothUsecase(boothRepo, transactioner)
+	agendaUsecase := usecases.NewAgendaUsecase(activityRepo, bookingRepo)
+	staffUsecase := usecases.NewStaffUsecase(staffRepo)
+	statsUsecase := usecases.NewStatsUsecase(statsRepo, cfg.Stats())
+	exportUsecase := usecases.NewExportUsecase(exportRepo)
+	seatStreamUsecase := usecases.NewSeatStreamUsecase(workshopRepo, cfg.Database())
+	notificationUsecase := usecases.NewNotificationUsecase(notificationRepo, newNotifier(cfg.Notifier()), cfg.Notifier())
+	sweepUsecase := usecases.NewSweepUsecase(bookingRepo, cfg.Sweeper())
+	webhookUsecase := usecases.NewWebhookUsecase(webhookRepo, transactioner, cfg.Webhooks())
+	eventDispatcher := usecases.NewEventDispatcher(eventRepo, cfg.Events())
+	idempotencyUsecase := usecases.NewIdempotencyUsecase(idempotencyRepo, cfg.Idempotency(), cfg.App().RequestTimeout)
+	rateLimitUsecase := usecases.NewRateLimitUsecase(rateLimitRepo, cfg.RateLimit())
+	metricsUsecase := usecases.NewMetricsUsecase()
+	expectedMigrationVersion, err := migrations.LatestVersion()
+	if err != nil {
+		return err
+	}
+	healthUsecase := usecases.NewHealthUsecase(healthRepo, expectedMigrationVersion)
+
+	// Event subscribers
+	eventDispatcher.Subscribe("webhooks", webhookUsecase.HandleEvent, models.EventTypes...)
+	eventDispatcher.Subscribe("metrics", metricsUsecase.HandleEvent, usecases.MetricsEventTypes...)
+
+	// Background workers
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	var workers sync.WaitGroup
+	for _, run := range []func(context.Context){
+		seatStreamUsecase.Run,
+		notificationUsecase.Run,
+		eventDispatcher.Run,
+		webhookUsecase.Run,
+		sweepUsecase.Run,
+		idempotencyUsecase.Run,
+		rateLimitUsecase.Run,
+	} {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run(workerCtx)
+		}()
+	}
+
+	// Initialize Middleware
+	firebaseAdapter := firebaseadapter.InitFirebaseAuthAdapter(ctx, cfg)
+	mid := middlewares.NewMiddleware(cfg, api, firebaseAdapter, staffUsecase, idempotencyUsecase, rateLimitUsecase)
+	api.UseMiddleware(mid.RequestTimeout)
 
 	// Register Handler
+	router.Handle("/metrics", metrics.Handler(cfg.Metrics().Token))
+	handlers.InitHealthHandler(api, healthUsecase)
+
 	userGroup := huma.NewGroup(api, "/users")
 	workshopGroup := huma.NewGroup(api, "/workshops")
+	// EventSource cannot send an Authorization header, so the seat stream is served without auth
+	workshopStreamGroup := huma.NewGroup(api, "/workshops")
 	checkInGroup := huma.NewGroup(api, "/check-in")
 	activityGroup := huma.NewGroup(api, "/activities")
 	stampGroup := huma.NewGroup(api, "/stamps")
+	adminGroup := huma.NewGroup(api, "/admin")
 
 	userGroup.UseMiddleware(mid.WithAuthContext)
 	workshopGroup.UseMiddleware(mid.WithAuthContext)
 	checkInGroup.UseMiddleware(mid.WithAuthContext)
 	activityGroup.UseMiddleware(mid.WithAuthContext)
 	stampGroup.UseMiddleware(mid.WithAuthContext)
+	// Every back-office endpoint requires some staff role, operations narrow it down further
+	adminGroup.UseMiddleware(mid.WithAuthContext, mid.RequireRole(models.StaffRoles...))
 
 	handlers.InitUserHandler(userGroup, userUsecase, stampUsecase, mid)
 	handlers.InitWorkshopHandler(workshopGroup, workshopUsecase, mid)
+	handlers.InitWorkshopStreamHandler(workshopStreamGroup, seatStreamUsecase, mid)
 	handlers.InitBookingHandler(workshopGroup, userGroup, bookingUsecase, userUsecase, mid)
-	handlers.InitCheckInHandler(checkInGroup, checkInUsecase, mid)
+	handlers.InitCheckInHandler(checkInGroup, userGroup, adminGroup, checkInUsecase, rateLimitUsecase, mid)
 	handlers.InitActivityHandler(activityGroup, activityUsecase, mid)
+	handlers.InitAgendaHandler(activityGroup, userGroup, agendaUsecase, userUsecase, mid)
 	handlers.InitStampHandler(stampGroup, userGroup, stampUsecase, userUsecase, mid)
+	handlers.InitStaffHandler(adminGroup, staffUsecase, mid)
+	handlers.InitAdminWorkshopHandler(adminGroup, workshopUsecase, mid)
+	handlers.InitAdminBoothHandler(adminGroup, boothUsecase, mid)
+	handlers.InitAdminActivityHandler(adminGroup, activityUsecase, mid)
+	handlers.InitAdminStatsHandler(adminGroup, statsUsecase, mid)
+	handlers.InitAdminExportHandler(adminGroup, exportUsecase, mid)
+	handlers.InitAdminWebhookHandler(adminGroup, webhookUsecase, mid)
+
+	srv := &http.Server{
+		Addr:              cfg.App().Address,
+		Handler:           router,
+		ReadHeaderTimeout: cfg.App().ReadHeaderTimeout,
+		ReadTimeout:       cfg.App().ReadTimeout,
+		WriteTimeout:      cfg.App().WriteTimeout,
+		IdleTimeout:       cfg.App().IdleTimeout,
+	}
+	// Streams never become idle, end them when the shutdown starts so it does not wait for them
+	srv.RegisterOnShutdown(seatStreamUsecase.Close)
+
+	return serve(srv, cfg.App().ShutdownTimeout, func(ctx context.Context) {
+		stopWorkers()
+		done := make(chan struct{})
+		go func() {
+			workers.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			slog.Error("background workers did not stop in time")
+		}
+
+		if err := db.Close(); err != nil {
+			slog.Error("failed to close database", "error", err)
+		}
+	})
+}
+
+// serve runs srv until SIGTERM or SIGINT, then stops accepting connections and waits up to shutdownTimeout
+// for in-flight requests before calling cleanup with what is left of the timeout. A second signal kills
+// the process right away.
+func serve(srv *http.Server, shutdownTimeout time.Duration, cleanup func(ctx context.Context)) error {
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stopSignals()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	var err error
+	select {
+	case err = <-serveErr:
+	case <-signalCtx.Done():
+		stopSignals()
+		slog.Info("shutting down", "timeout", shutdownTimeout.String())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err == nil {
+		if err = srv.Shutdown(ctx); err != nil {
+			slog.Error("in-flight requests did not finish in time", "error", err)
+		}
+	}
+	cleanup(ctx)
+	return err
+}
+
+func newRateLimitRepo(cfg config.RateLimit, db *bun.DB) repositories.RateLimitRepo {
+	if cfg.Backend == "memory" {
+		slog.Warn("rate limit: buckets are kept in memory, limits are not shared between instances")
+		return repositories.NewMemoryRateLimitRepo()
+	}
+	return repositories.NewRateLimitRepo(db)
+}
 
-	if err := http.ListenAndServe(cfg.App().Address, router); err != nil {
-		log.Fatal(err)
+func newNotifier(cfg config.Notifier) notifier.Notifier {
+	if cfg.SMTPHost == "" {
+		slog.Warn("notifier: no SMTP host configured, notifications are only logged")
+		return notifier.NewLogNotifier()
 	}
 
-	return nil
+	return notifier.NewSMTPNotifier(notifier.SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.From,
+	})
 }
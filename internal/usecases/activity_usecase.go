@@ -2,30 +2,166 @@ package usecases
 
 import (
 	"context"
+	"errors"
 
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/myValidator"
 )
 
+var ErrInvalidActivityTime = errors.New("end time must be after start time")
+
 type ActivityUsecase interface {
 	GetActivity(ctx context.Context, id int64) (*models.Activity, error)
 	ListActivities(ctx context.Context, filter models.ActivityFilter) ([]*models.Activity, error)
+	CreateActivity(ctx context.Context, activity *models.Activity) error
+	ImportActivities(ctx context.Context, activities []*models.Activity) error
+	UpdateActivity(ctx context.Context, id int64, update *models.ActivityOptional) (*models.Activity, error)
+	ArchiveActivity(ctx context.Context, id int64) error
 }
 
 type activityUsecaseImpl struct {
-	repo repositories.ActivityRepo
+	repo          repositories.ActivityRepo
+	transactioner baserepo.Transactioner
 }
 
-func NewActivityUsecase(repo repositories.ActivityRepo) ActivityUsecase {
+func NewActivityUsecase(repo repositories.ActivityRepo, transactioner baserepo.Transactioner) ActivityUsecase {
 	return &activityUsecaseImpl{
-		repo: repo,
+		repo:          repo,
+		transactioner: transactioner,
 	}
 }
 
 func (u *activityUsecaseImpl) GetActivity(ctx context.Context, id int64) (*models.Activity, error) {
+	ctx, span := tracer.Start(ctx, "ActivityUsecase.GetActivity")
+	defer span.End()
+
 	return u.repo.GetActivityByID(ctx, id)
 }
 
 func (u *activityUsecaseImpl) ListActivities(ctx context.Context, filter models.ActivityFilter) ([]*models.Activity, error) {
+	ctx, span := tracer.Start(ctx, "ActivityUsecase.ListActivities")
+	defer span.End()
+
 	return u.repo.ListActivities(ctx, filter)
 }
+
+func (u *activityUsecaseImpl) CreateActivity(ctx context.Context, activity *models.Activity) error {
+	ctx, span := tracer.Start(ctx, "ActivityUsecase.CreateActivity")
+	defer span.End()
+
+	return u.ImportActivities(ctx, []*models.Activity{activity})
+}
+
+// ImportActivities inserts all activities or none of them.
+func (u *activityUsecaseImpl) ImportActivities(ctx context.Context, activities []*models.Activity) error {
+	ctx, span := tracer.Start(ctx, "ActivityUsecase.ImportActivities")
+	defer span.End()
+
+	if len(activities) == 0 {
+		return ErrEmptyImport
+	}
+
+	for i, activity := range activities {
+		if err := myValidator.ValidateEventDate(activity.EventDate); err != nil {
+			return &ImportItemError{Index: i, Err: err}
+		}
+		if err := validateActivity(activity); err != nil {
+			return &ImportItemError{Index: i, Err: err}
+		}
+	}
+
+	return u.repo.CreateActivities(ctx, activities)
+}
+
+func (u *activityUsecaseImpl) UpdateActivity(ctx context.Context, id int64, update *models.ActivityOptional) (*models.Activity, error) {
+	ctx, span := tracer.Start(ctx, "ActivityUsecase.UpdateActivity")
+	defer span.End()
+
+	if update.EventDate != nil {
+		if err := myValidator.ValidateEventDate(*update.EventDate); err != nil {
+			return nil, err
+		}
+	}
+
+	var activity *models.Activity
+	err := u.transactioner.Transaction(ctx, func(ctx context.Context) error {
+		current, err := u.repo.GetActivityForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		merged, changed := mergeActivity(current, update)
+		if !changed {
+			return ErrNothingToUpdate
+		}
+		if err := validateActivity(merged); err != nil {
+			return err
+		}
+
+		if err := u.repo.UpdateActivity(ctx, id, update); err != nil {
+			return err
+		}
+		activity = merged
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return activity, nil
+}
+
+func (u *activityUsecaseImpl) ArchiveActivity(ctx context.Context, id int64) error {
+	ctx, span := tracer.Start(ctx, "ActivityUsecase.ArchiveActivity")
+	defer span.End()
+
+	return u.repo.ArchiveActivity(ctx, id)
+}
+
+// mergeActivity applies the non-nil fields of update on a copy of current.
+func mergeActivity(current *models.Activity, update *models.ActivityOptional) (*models.Activity, bool) {
+	merged := *current
+	changed := false
+
+	if update.Title != nil {
+		merged.Title, changed = *update.Title, true
+	}
+	if update.Description != nil {
+		merged.Description, changed = *update.Description, true
+	}
+	if update.StartTime != nil {
+		merged.StartTime, changed = *update.StartTime, true
+	}
+	if update.EndTime != nil {
+		merged.EndTime, changed = *update.EndTime, true
+	}
+	if update.EventDate != nil {
+		merged.EventDate, changed = *update.EventDate, true
+	}
+	if update.BuildingName != nil {
+		merged.BuildingName, changed = update.BuildingName, true
+	}
+	if update.Floor != nil {
+		merged.Floor, changed = update.Floor, true
+	}
+	if update.RoomName != nil {
+		merged.RoomName, changed = update.RoomName, true
+	}
+	if update.Image != nil {
+		merged.Image, changed = update.Image, true
+	}
+	if update.Link != nil {
+		merged.Link, changed = update.Link, true
+	}
+
+	return &merged, changed
+}
+
+func validateActivity(activity *models.Activity) error {
+	if !activity.EndTime.After(activity.StartTime) {
+		return ErrInvalidActivityTime
+	}
+	return nil
+}
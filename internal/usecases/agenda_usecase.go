@@ -0,0 +1,184 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/ical"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/utils"
+)
+
+const (
+	agendaCalendarName   = "Intania Openhouse 2026"
+	agendaCalendarProdID = "-//Intania Openhouse 2026//Agenda//EN"
+	// agendaUIDDomain makes event UIDs globally unique as RFC 5545 asks
+	agendaUIDDomain = "intania-openhouse-2026"
+)
+
+// AgendaUsecase merges the booked workshops and starred activities of a user.
+type AgendaUsecase interface {
+	// SaveActivity stars an activity, starring it again does nothing.
+	SaveActivity(ctx context.Context, userID int64, activityID int64) error
+	UnsaveActivity(ctx context.Context, userID int64, activityID int64) error
+	// GetAgenda returns the agenda sorted by date and start time, with overlapping items flagged.
+	GetAgenda(ctx context.Context, userID int64) ([]models.AgendaItem, error)
+	// ExportAgendaICS returns the agenda as an iCalendar file in Asia/Bangkok.
+	ExportAgendaICS(ctx context.Context, userID int64) ([]byte, error)
+}
+
+type agendaUsecaseImpl struct {
+	activityRepo repositories.ActivityRepo
+	bookingRepo  repositories.BookingRepo
+}
+
+func NewAgendaUsecase(activityRepo repositories.ActivityRepo, bookingRepo repositories.BookingRepo) AgendaUsecase {
+	return &agendaUsecaseImpl{
+		activityRepo: activityRepo,
+		bookingRepo:  bookingRepo,
+	}
+}
+
+func (u *agendaUsecaseImpl) SaveActivity(ctx context.Context, userID int64, activityID int64) error {
+	ctx, span := tracer.Start(ctx, "AgendaUsecase.SaveActivity")
+	defer span.End()
+
+	// Archived activities cannot be starred
+	if _, err := u.activityRepo.GetActivityByID(ctx, activityID); err != nil {
+		return err
+	}
+	return u.activityRepo.SaveActivity(ctx, userID, activityID)
+}
+
+func (u *agendaUsecaseImpl) UnsaveActivity(ctx context.Context, userID int64, activityID int64) error {
+	ctx, span := tracer.Start(ctx, "AgendaUsecase.UnsaveActivity")
+	defer span.End()
+
+	return u.activityRepo.UnsaveActivity(ctx, userID, activityID)
+}
+
+func (u *agendaUsecaseImpl) GetAgenda(ctx context.Context, userID int64) ([]models.AgendaItem, error) {
+	ctx, span := tracer.Start(ctx, "AgendaUsecase.GetAgenda")
+	defer span.End()
+
+	bookings, err := u.bookingRepo.GetUserBookings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	activities, err := u.activityRepo.GetSavedActivities(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.AgendaItem, 0, len(bookings)+len(activities))
+	for _, b := range bookings {
+		// Absent bookings are past workshops the user did not attend
+		if b.Status != models.StatusConfirmed && b.Status != models.StatusAttended {
+			continue
+		}
+		items = append(items, models.AgendaItem{
+			Kind:          models.AgendaItemWorkshop,
+			ID:            b.WorkshopID,
+			Title:         b.WorkshopName,
+			EventDate:     b.EventDate,
+			StartTime:     b.StartTime,
+			EndTime:       b.EndTime,
+			Location:      b.Location,
+			BookingStatus: &b.Status,
+		})
+	}
+	for _, a := range activities {
+		items = append(items, models.AgendaItem{
+			Kind:        models.AgendaItemActivity,
+			ID:          a.ID,
+			Title:       a.Title,
+			Description: a.Description,
+			EventDate:   a.EventDate,
+			StartTime:   a.StartTime,
+			EndTime:     a.EndTime,
+			Location:    activityLocation(a),
+			Link:        a.Link,
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].EventDate != items[j].EventDate {
+			return items[i].EventDate < items[j].EventDate
+		}
+		return items[i].StartTime.Before(items[j].StartTime)
+	})
+
+	// Items are sorted by start, so an item can only overlap the ones after it until one starts after it ends
+	for i := range items {
+		for j := i + 1; j < len(items) && items[j].EventDate == items[i].EventDate; j++ {
+			if !items[j].StartTime.Before(items[i].EndTime) {
+				break
+			}
+			items[i].HasConflict = true
+			items[j].HasConflict = true
+		}
+	}
+
+	return items, nil
+}
+
+func (u *agendaUsecaseImpl) ExportAgendaICS(ctx context.Context, userID int64) ([]byte, error) {
+	ctx, span := tracer.Start(ctx, "AgendaUsecase.ExportAgendaICS")
+	defer span.End()
+
+	items, err := u.GetAgenda(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &ical.Calendar{
+		ProdID:   agendaCalendarProdID,
+		Name:     agendaCalendarName,
+		Location: utils.BangkokLocation,
+		Events:   make([]ical.Event, 0, len(items)),
+	}
+	for _, item := range items {
+		start, err := workshopDateTime(item.EventDate, item.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		end, err := workshopDateTime(item.EventDate, item.EndTime)
+		if err != nil {
+			return nil, err
+		}
+
+		event := ical.Event{
+			UID:         fmt.Sprintf("%s-%d@%s", item.Kind, item.ID, agendaUIDDomain),
+			Start:       start,
+			End:         end,
+			Summary:     item.Title,
+			Location:    item.Location,
+			Description: item.Description,
+		}
+		if item.Link != nil {
+			event.URL = *item.Link
+		}
+		calendar.Events = append(calendar.Events, event)
+	}
+
+	var buf bytes.Buffer
+	if err := calendar.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// activityLocation joins the building, floor and room of an activity.
+func activityLocation(activity *models.Activity) string {
+	parts := make([]string, 0, 3)
+	for _, p := range []*string{activity.BuildingName, activity.Floor, activity.RoomName} {
+		if p != nil && *p != "" {
+			parts = append(parts, *p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
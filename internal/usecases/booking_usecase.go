@@ -3,110 +3,802 @@ package usecases
 import (
 	"context"
 	"errors"
+	"slices"
 	"sort"
 	"time"
 
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/metrics"
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
 	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/config"
 )
 
 var (
 	ErrTimeConflict              = errors.New("time conflict with existing booking")
 	ErrParticipantTypeNotAllowed = errors.New("participant type is not allowed")
 	ErrBookingNotFound           = errors.New("booking not found")
+	ErrWorkshopNotFull           = errors.New("workshop still has available seats")
+	ErrParticipantTypeInUse      = errors.New("participant type change conflicts with existing bookings")
+	ErrCancellationClosed        = errors.New("booking can no longer be cancelled")
+	ErrBookingLimitReached       = errors.New("maximum number of confirmed bookings reached")
+	ErrBookingSuspended          = errors.New("booking is suspended after too many absences")
+	ErrBookingNotOpen            = errors.New("booking has not opened yet")
+	ErrBookingClosed             = errors.New("booking has closed")
+	ErrSeatsNotReleased          = errors.New("every released seat is taken, more seats are released later")
+	ErrGroupLeaderNotAllowed     = errors.New("only teachers and parents can make group bookings")
+	ErrGroupTooLarge             = errors.New("group booking has too many companions")
+	ErrGroupSeatLimitReached     = errors.New("maximum number of group seats for this workshop reached")
+	ErrNotEnoughSeats            = errors.New("not enough seats left for the whole group")
+	ErrGroupSeatClaimed          = errors.New("group seat was already claimed")
 )
 
+// groupLeaderTypes may make group bookings, their companions take seats as groupCompanionType.
+var groupLeaderTypes = []models.ParticipantType{models.ParticipantTypeTeacher, models.ParticipantTypeOther}
+
+const groupCompanionType = models.ParticipantTypeStudent
+
 type BookingUsecase interface {
 	BookWorkshop(ctx context.Context, userID int64, userEmail string, workshopID int64) error
 	CancelBooking(ctx context.Context, userID int64, workshopID int64) error
 	GetMyBookings(ctx context.Context, userID int64) ([]models.BookingWithWorkshop, error)
 	UpdateBookingStatus(ctx context.Context, bookingID int64, status models.Status) error
+	JoinWaitlist(ctx context.Context, userID int64, userEmail string, workshopID int64) error
+	LeaveWaitlist(ctx context.Context, userID int64, workshopID int64) error
+	GetMyWaitlist(ctx context.Context, userID int64) ([]models.WaitlistEntryWithWorkshop, error)
+	CancelAllBookings(ctx context.Context, userID int64) error
+	CheckParticipantTypeChange(ctx context.Context, userID int64, participantType models.ParticipantType) error
+
+	// CreateGroupBooking reserves a seat for each companion in one transaction, the leader does not take a seat.
+	CreateGroupBooking(ctx context.Context, leaderID int64, leaderEmail string, workshopID int64, companionNames []string) (*models.BookingGroupWithWorkshop, error)
+	GetMyGroupBookings(ctx context.Context, leaderID int64) ([]models.BookingGroupWithWorkshop, error)
+	// CancelGroupSeat releases a seat that has not been claimed yet.
+	CancelGroupSeat(ctx context.Context, leaderID int64, groupID int64, seatID int64) error
+	// CancelGroupBooking releases every seat of the group that has not been claimed yet.
+	CancelGroupBooking(ctx context.Context, leaderID int64, groupID int64) error
+	// ClaimGroupSeat turns a reserved group seat into a booking of the user.
+	ClaimGroupSeat(ctx context.Context, userID int64, userEmail string, claimCode string) error
 }
 
 type bookingUsecaseImpl struct {
-	bookingRepo   repositories.BookingRepo
-	workshopRepo  repositories.WorkshopRepo
-	userRepo      repositories.UserRepo
-	transactioner baserepo.Transactioner
+	bookingRepo      repositories.BookingRepo
+	workshopRepo     repositories.WorkshopRepo
+	userRepo         repositories.UserRepo
+	waitlistRepo     repositories.WaitlistRepo
+	groupBookingRepo repositories.GroupBookingRepo
+	notificationRepo repositories.NotificationRepo
+	eventRepo        repositories.EventRepo
+	transactioner    baserepo.Transactioner
+	policy           config.BookingPolicy
 }
 
 func NewBookingUsecase(
 	bookingRepo repositories.BookingRepo,
 	workshopRepo repositories.WorkshopRepo,
 	userRepo repositories.UserRepo,
+	waitlistRepo repositories.WaitlistRepo,
+	groupBookingRepo repositories.GroupBookingRepo,
+	notificationRepo repositories.NotificationRepo,
+	eventRepo repositories.EventRepo,
 	transactioner baserepo.Transactioner,
+	policy config.BookingPolicy,
 ) BookingUsecase {
 	return &bookingUsecaseImpl{
-		bookingRepo:   bookingRepo,
-		workshopRepo:  workshopRepo,
-		userRepo:      userRepo,
-		transactioner: transactioner,
+		bookingRepo:      bookingRepo,
+		workshopRepo:     workshopRepo,
+		userRepo:         userRepo,
+		waitlistRepo:     waitlistRepo,
+		groupBookingRepo: groupBookingRepo,
+		notificationRepo: notificationRepo,
+		eventRepo:        eventRepo,
+		transactioner:    transactioner,
+		policy:           policy,
 	}
 }
 
+var bookingWorkshopFields = []string{"id", "event_date", "start_time", "end_time", "total_seats", "registered_count", "category", "booking_opens_at", "booking_closes_at"}
+
+// bookingConflictReasons labels the booking rules counted by metrics.BookingConflicts.
+var bookingConflictReasons = map[error]string{
+	repositories.ErrAlreadyBooked: "already_booked",
+	repositories.ErrWorkshopFull:  "workshop_full",
+	repositories.ErrSeatsReserved: "seats_reserved",
+	ErrSeatsNotReleased:           "seats_not_released",
+	ErrTimeConflict:               "time_conflict",
+	ErrParticipantTypeNotAllowed:  "participant_type_not_allowed",
+	ErrBookingLimitReached:        "booking_limit_reached",
+	ErrBookingSuspended:           "booking_suspended",
+	ErrBookingNotOpen:             "booking_not_open",
+	ErrBookingClosed:              "booking_closed",
+}
+
 func (u *bookingUsecaseImpl) BookWorkshop(ctx context.Context, userID int64, userEmail string, workshopID int64) error {
-	workshop, err := u.workshopRepo.GetWorkshopById(ctx, workshopID, []string{"id", "event_date", "start_time", "end_time", "total_seats", "registered_count", "category"})
+	ctx, span := tracer.Start(ctx, "BookingUsecase.BookWorkshop")
+	defer span.End()
+
+	err := u.bookWorkshop(ctx, userID, userEmail, workshopID)
+	if reason, ok := bookingConflictReasons[err]; ok {
+		metrics.BookingConflicts.WithLabelValues(reason).Inc()
+	}
+	return err
+}
+
+func (u *bookingUsecaseImpl) bookWorkshop(ctx context.Context, userID int64, userEmail string, workshopID int64) error {
+	workshop, err := u.workshopRepo.GetWorkshopById(ctx, workshopID, bookingWorkshopFields)
 	if err != nil {
 		return err
 	}
+	if err := checkBookingWindow(workshop); err != nil {
+		return err
+	}
 	if *workshop.RegisteredCount >= *workshop.TotalSeats {
 		return repositories.ErrWorkshopFull
 	}
+	releasedSeats, err := u.workshopRepo.GetReleasedSeats(ctx, workshopID)
+	if err != nil {
+		return err
+	}
+	if *workshop.RegisteredCount >= releasedSeats {
+		return ErrSeatsNotReleased
+	}
 
-	// check participant type
 	user, err := u.userRepo.GetUserByEmail(ctx, userEmail, []string{"participant_type"})
 	if err != nil {
 		return err
 	}
-	switch user.ParticipantType {
-	case models.ParticipantTypeAlumni, models.ParticipantTypeTeacher, models.ParticipantTypeOther:
-		return ErrParticipantTypeNotAllowed
+	if err := u.checkBookingEligibility(ctx, userID, user.ParticipantType, workshop); err != nil {
+		return err
+	}
+
+	return u.transactioner.Transaction(ctx, func(ctx context.Context) error {
+		booking := &models.Booking{
+			UserID:          userID,
+			WorkshopID:      workshopID,
+			Status:          models.StatusConfirmed,
+			CreatedAt:       time.Now(),
+			ParticipantType: user.ParticipantType,
+		}
+		if err := u.bookingRepo.CreateBooking(ctx, booking); err != nil {
+			return err
+		}
+		if err := u.workshopRepo.IncrementRegisteredCount(ctx, workshopID, user.ParticipantType, true); err != nil {
+			return err
+		}
+		if err := u.notificationRepo.EnqueueNotification(ctx, models.NotificationBookingConfirmed, booking.ID); err != nil {
+			return err
+		}
+		if err := u.eventRepo.AppendEvent(ctx, models.EventBookingCreated, models.BookingEventPayload{
+			BookingID:  booking.ID,
+			UserID:     userID,
+			WorkshopID: workshopID,
+		}); err != nil {
+			return err
+		}
+		// A user who got a seat directly no longer needs their place in the queue
+		if err := u.waitlistRepo.DeleteWaitlistEntry(ctx, userID, workshopID); err != nil && err != repositories.ErrWaitlistEntryNotFound {
+			return err
+		}
+		return nil
+	})
+}
+
+func (u *bookingUsecaseImpl) CancelBooking(ctx context.Context, userID int64, workshopID int64) error {
+	ctx, span := tracer.Start(ctx, "BookingUsecase.CancelBooking")
+	defer span.End()
+
+	if err := u.checkCancellationCutoff(ctx, workshopID); err != nil {
+		return err
+	}
+	return u.cancelBooking(ctx, userID, workshopID)
+}
+
+// checkCancellationCutoff rejects cancellations within the policy cutoff before the workshop starts.
+func (u *bookingUsecaseImpl) checkCancellationCutoff(ctx context.Context, workshopID int64) error {
+	if u.policy.CancellationCutoff <= 0 {
+		return nil
+	}
+
+	workshop, err := u.workshopRepo.GetWorkshopById(ctx, workshopID, []string{"event_date", "start_time"})
+	if err != nil {
+		if err == repositories.ErrWorkshopNotFound {
+			return repositories.ErrBookingNotFound
+		}
+		return err
+	}
+	start, err := workshopDateTime(*workshop.EventDate, *workshop.StartTime)
+	if err != nil {
+		return err
+	}
+	if time.Now().After(start.Add(-u.policy.CancellationCutoff)) {
+		return ErrCancellationClosed
+	}
+	return nil
+}
+
+func (u *bookingUsecaseImpl) cancelBooking(ctx context.Context, userID int64, workshopID int64) error {
+	return u.transactioner.Transaction(ctx, func(ctx context.Context) error {
+		bookingID, err := u.bookingRepo.CancelBooking(ctx, userID, workshopID)
+		if err != nil {
+			return err
+		}
+		if err := u.workshopRepo.DecrementRegisteredCount(ctx, workshopID); err != nil {
+			return err
+		}
+		if err := u.notificationRepo.EnqueueNotification(ctx, models.NotificationBookingCancelled, bookingID); err != nil {
+			return err
+		}
+		if err := u.eventRepo.AppendEvent(ctx, models.EventBookingCancelled, models.BookingEventPayload{
+			BookingID:  bookingID,
+			UserID:     userID,
+			WorkshopID: workshopID,
+		}); err != nil {
+			return err
+		}
+		return u.promoteFromWaitlist(ctx, workshopID)
+	})
+}
+
+// promoteFromWaitlist gives the freed seat to the first user in the queue who is still eligible.
+// It must be called inside the transaction that freed the seat.
+func (u *bookingUsecaseImpl) promoteFromWaitlist(ctx context.Context, workshopID int64) error {
+	entries, err := u.waitlistRepo.GetWorkshopWaitlist(ctx, workshopID)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	workshop, err := u.workshopRepo.GetWorkshopById(ctx, workshopID, bookingWorkshopFields)
+	if err != nil {
+		return err
 	}
-	// check for club's workshop
-	if *workshop.Category == models.WorkShopCategoryClub && user.ParticipantType != models.ParticipantTypeStudent {
+
+	for _, entry := range entries {
+		user, err := u.userRepo.GetUserByID(ctx, entry.UserID, []string{"participant_type"})
+		if err != nil {
+			return err
+		}
+
+		err = u.checkBookingEligibility(ctx, entry.UserID, user.ParticipantType, workshop)
+		switch err {
+		case nil:
+		case repositories.ErrAlreadyBooked:
+			if err := u.waitlistRepo.DeleteWaitlistEntry(ctx, entry.UserID, workshopID); err != nil {
+				return err
+			}
+			continue
+		case ErrParticipantTypeNotAllowed, ErrTimeConflict, ErrBookingLimitReached, ErrBookingSuspended:
+			// Keep the entry, the user may become eligible again after cancelling another booking
+			continue
+		default:
+			return err
+		}
+
+		// The user joined the waitlist while booking was open, promotion continues after it closes
+		err = u.workshopRepo.IncrementRegisteredCount(ctx, workshopID, user.ParticipantType, false)
+		switch err {
+		case nil:
+		case repositories.ErrSeatsReserved:
+			// The freed seat is reserved for another participant type, a later entry may take it
+			continue
+		case repositories.ErrWorkshopFull:
+			return nil
+		default:
+			return err
+		}
+		booking := &models.Booking{
+			UserID:          entry.UserID,
+			WorkshopID:      workshopID,
+			Status:          models.StatusConfirmed,
+			CreatedAt:       time.Now(),
+			ParticipantType: user.ParticipantType,
+		}
+		if err := u.bookingRepo.CreateBooking(ctx, booking); err != nil {
+			return err
+		}
+		if err := u.notificationRepo.EnqueueNotification(ctx, models.NotificationBookingConfirmed, booking.ID); err != nil {
+			return err
+		}
+		if err := u.eventRepo.AppendEvent(ctx, models.EventBookingCreated, models.BookingEventPayload{
+			BookingID:    booking.ID,
+			UserID:       entry.UserID,
+			WorkshopID:   workshopID,
+			FromWaitlist: true,
+		}); err != nil {
+			return err
+		}
+		return u.waitlistRepo.DeleteWaitlistEntry(ctx, entry.UserID, workshopID)
+	}
+
+	return nil
+}
+
+// checkBookingWindow rejects bookings outside the booking window of the workshop.
+func checkBookingWindow(workshop *models.WorkshopOptional) error {
+	now := time.Now()
+	if workshop.BookingOpensAt != nil && now.Before(*workshop.BookingOpensAt) {
+		return ErrBookingNotOpen
+	}
+	if workshop.BookingClosesAt != nil && !now.Before(*workshop.BookingClosesAt) {
+		return ErrBookingClosed
+	}
+	return nil
+}
+
+// checkBookingEligibility verifies the participant type rules, that the user holds no
+// conflicting booking and the booking policy. The workshop must be loaded with bookingWorkshopFields.
+func (u *bookingUsecaseImpl) checkBookingEligibility(ctx context.Context, userID int64, participantType models.ParticipantType, workshop *models.WorkshopOptional) error {
+	rules, err := u.workshopRepo.GetParticipantRules(ctx, *workshop.ID)
+	if err != nil {
+		return err
+	}
+	if !participantTypeAllowed(participantType, rules) {
 		return ErrParticipantTypeNotAllowed
 	}
 
-	// Get user's existing confirmed bookings for the same date (with time info)
+	// Get user's existing bookings (with time info)
 	existingBookings, err := u.bookingRepo.GetUserBookings(ctx, userID)
 	if err != nil {
 		return err
 	}
-	// Check for time overlap
+	// Check for duplicate booking and time overlap
 	targetStart := *workshop.StartTime
 	targetEnd := *workshop.EndTime
 	for _, b := range existingBookings {
+		if b.WorkshopID == *workshop.ID {
+			return repositories.ErrAlreadyBooked
+		}
 		if targetStart.Before(b.EndTime) && targetEnd.After(b.StartTime) && *workshop.EventDate == b.EventDate && b.Status == models.StatusConfirmed {
 			return ErrTimeConflict
 		}
 	}
 
+	return u.checkBookingPolicy(existingBookings)
+}
+
+// checkBookingPolicy applies the booking limits of the policy to the existing bookings of a user.
+func (u *bookingUsecaseImpl) checkBookingPolicy(bookings []models.BookingWithWorkshop) error {
+	now := time.Now()
+	confirmed, absences := 0, 0
+	for _, b := range bookings {
+		switch b.Status {
+		case models.StatusAbsent:
+			absences++
+		case models.StatusConfirmed:
+			end, err := workshopDateTime(b.EventDate, b.EndTime)
+			if err != nil {
+				return err
+			}
+			// The sweeper has not marked it Absent yet
+			if now.After(end) {
+				absences++
+			} else {
+				confirmed++
+			}
+		}
+	}
+
+	if u.policy.MaxAbsences > 0 && absences >= u.policy.MaxAbsences {
+		return ErrBookingSuspended
+	}
+	if u.policy.MaxConfirmedBookings > 0 && confirmed >= u.policy.MaxConfirmedBookings {
+		return ErrBookingLimitReached
+	}
+	return nil
+}
+
+// participantTypeAllowed reports whether the participant rules of a workshop admit participantType.
+func participantTypeAllowed(participantType models.ParticipantType, rules []models.ParticipantRule) bool {
+	if len(rules) == 0 {
+		return true
+	}
+	for _, r := range rules {
+		if r.ParticipantType == participantType {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckParticipantTypeChange rejects a participant type under which the user could not have made
+// their confirmed bookings.
+func (u *bookingUsecaseImpl) CheckParticipantTypeChange(ctx context.Context, userID int64, participantType models.ParticipantType) error {
+	ctx, span := tracer.Start(ctx, "BookingUsecase.CheckParticipantTypeChange")
+	defer span.End()
+
+	bookings, err := u.bookingRepo.GetUserBookings(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range bookings {
+		if b.Status != models.StatusConfirmed {
+			continue
+		}
+		rules, err := u.workshopRepo.GetParticipantRules(ctx, b.WorkshopID)
+		if err != nil {
+			return err
+		}
+		if !participantTypeAllowed(participantType, rules) {
+			return ErrParticipantTypeInUse
+		}
+	}
+	return nil
+}
+
+// CancelAllBookings releases every confirmed booking, unclaimed group seat and waitlist place of a user
+// regardless of the cancellation cutoff, each freed seat is offered to the waitlist as in CancelBooking.
+func (u *bookingUsecaseImpl) CancelAllBookings(ctx context.Context, userID int64) error {
+	ctx, span := tracer.Start(ctx, "BookingUsecase.CancelAllBookings")
+	defer span.End()
+
 	return u.transactioner.Transaction(ctx, func(ctx context.Context) error {
-		booking := &models.Booking{
-			UserID:     userID,
-			WorkshopID: workshopID,
-			Status:     models.StatusConfirmed,
-			CreatedAt:  time.Now(),
+		// Leave the queues first so the user cannot be promoted into a freed seat
+		if err := u.waitlistRepo.DeleteUserWaitlistEntries(ctx, userID); err != nil {
+			return err
 		}
-		if err := u.bookingRepo.CreateBooking(ctx, booking); err != nil {
+
+		bookings, err := u.bookingRepo.GetUserBookings(ctx, userID)
+		if err != nil {
 			return err
 		}
-		return u.workshopRepo.IncrementRegisteredCount(ctx, workshopID)
+		for _, b := range bookings {
+			if b.Status != models.StatusConfirmed {
+				continue
+			}
+			if err := u.cancelBooking(ctx, userID, b.WorkshopID); err != nil {
+				return err
+			}
+		}
+
+		groups, err := u.groupBookingRepo.GetLeaderGroups(ctx, userID)
+		if err != nil {
+			return err
+		}
+		for _, g := range groups {
+			if err := u.cancelGroupSeats(ctx, g.WorkshopID, g.ID, nil); err != nil {
+				return err
+			}
+		}
+		return nil
 	})
 }
 
-func (u *bookingUsecaseImpl) CancelBooking(ctx context.Context, userID int64, workshopID int64) error {
+func (u *bookingUsecaseImpl) CreateGroupBooking(ctx context.Context, leaderID int64, leaderEmail string, workshopID int64, companionNames []string) (*models.BookingGroupWithWorkshop, error) {
+	ctx, span := tracer.Start(ctx, "BookingUsecase.CreateGroupBooking")
+	defer span.End()
+
+	if u.policy.MaxGroupSize > 0 && len(companionNames) > u.policy.MaxGroupSize {
+		return nil, ErrGroupTooLarge
+	}
+
+	leader, err := u.userRepo.GetUserByEmail(ctx, leaderEmail, []string{"participant_type"})
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(groupLeaderTypes, leader.ParticipantType) {
+		return nil, ErrGroupLeaderNotAllowed
+	}
+	// A leader with too many absences or bookings cannot hold seats for others either
+	leaderBookings, err := u.bookingRepo.GetUserBookings(ctx, leaderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.checkBookingPolicy(leaderBookings); err != nil {
+		return nil, err
+	}
+
+	workshop, err := u.workshopRepo.GetWorkshopById(ctx, workshopID, append([]string{"name", "location"}, bookingWorkshopFields...))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkBookingWindow(workshop); err != nil {
+		return nil, err
+	}
+	rules, err := u.workshopRepo.GetParticipantRules(ctx, workshopID)
+	if err != nil {
+		return nil, err
+	}
+	if !participantTypeAllowed(groupCompanionType, rules) {
+		return nil, ErrParticipantTypeNotAllowed
+	}
+	if *workshop.RegisteredCount+len(companionNames) > *workshop.TotalSeats {
+		return nil, ErrNotEnoughSeats
+	}
+	releasedSeats, err := u.workshopRepo.GetReleasedSeats(ctx, workshopID)
+	if err != nil {
+		return nil, err
+	}
+	if *workshop.RegisteredCount+len(companionNames) > releasedSeats {
+		return nil, ErrSeatsNotReleased
+	}
+
+	group := &models.BookingGroup{
+		WorkshopID: workshopID,
+		LeaderID:   leaderID,
+		CreatedAt:  time.Now(),
+	}
+	seats := make([]models.GroupSeat, 0, len(companionNames))
+	err = u.transactioner.Transaction(ctx, func(ctx context.Context) error {
+		if u.policy.MaxGroupSize > 0 {
+			// The workshop lock serializes concurrent groups of the same leader
+			if _, err := u.workshopRepo.GetWorkshopForUpdate(ctx, workshopID); err != nil {
+				return err
+			}
+			held, err := u.groupBookingRepo.CountLeaderSeats(ctx, leaderID, workshopID)
+			if err != nil {
+				return err
+			}
+			if held+len(companionNames) > u.policy.MaxGroupSize {
+				return ErrGroupSeatLimitReached
+			}
+		}
+		if err := u.groupBookingRepo.CreateGroup(ctx, group); err != nil {
+			return err
+		}
+		for _, name := range companionNames {
+			// Every seat goes through the same guard as a single booking, the whole group rolls back if one fails
+			if err := u.workshopRepo.IncrementRegisteredCount(ctx, workshopID, groupCompanionType, true); err != nil {
+				if err == repositories.ErrWorkshopFull {
+					return ErrNotEnoughSeats
+				}
+				return err
+			}
+			seats = append(seats, models.GroupSeat{
+				GroupID:         group.ID,
+				CompanionName:   name,
+				ParticipantType: groupCompanionType,
+				Status:          models.GroupSeatReserved,
+				CreatedAt:       group.CreatedAt,
+			})
+		}
+		return u.groupBookingRepo.CreateSeats(ctx, seats)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BookingGroupWithWorkshop{
+		ID:           group.ID,
+		WorkshopID:   workshopID,
+		CreatedAt:    group.CreatedAt,
+		WorkshopName: *workshop.Name,
+		EventDate:    *workshop.EventDate,
+		StartTime:    *workshop.StartTime,
+		EndTime:      *workshop.EndTime,
+		Location:     *workshop.Location,
+		Seats:        seats,
+	}, nil
+}
+
+func (u *bookingUsecaseImpl) GetMyGroupBookings(ctx context.Context, leaderID int64) ([]models.BookingGroupWithWorkshop, error) {
+	ctx, span := tracer.Start(ctx, "BookingUsecase.GetMyGroupBookings")
+	defer span.End()
+
+	groups, err := u.groupBookingRepo.GetLeaderGroups(ctx, leaderID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupIDs := make([]int64, 0, len(groups))
+	for _, g := range groups {
+		groupIDs = append(groupIDs, g.ID)
+	}
+	seats, err := u.groupBookingRepo.GetGroupsSeats(ctx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	seatsByGroup := make(map[int64][]models.GroupSeat, len(groups))
+	for _, s := range seats {
+		seatsByGroup[s.GroupID] = append(seatsByGroup[s.GroupID], s)
+	}
+	for i := range groups {
+		groups[i].Seats = seatsByGroup[groups[i].ID]
+	}
+	return groups, nil
+}
+
+func (u *bookingUsecaseImpl) CancelGroupSeat(ctx context.Context, leaderID int64, groupID int64, seatID int64) error {
+	ctx, span := tracer.Start(ctx, "BookingUsecase.CancelGroupSeat")
+	defer span.End()
+
+	return u.transactioner.Transaction(ctx, func(ctx context.Context) error {
+		group, err := u.groupBookingRepo.GetGroupForUpdate(ctx, groupID, leaderID)
+		if err != nil {
+			return err
+		}
+		seat, err := u.groupBookingRepo.GetSeatForUpdate(ctx, groupID, seatID)
+		if err != nil {
+			return err
+		}
+		switch seat.Status {
+		case models.GroupSeatClaimed:
+			// The seat is the booking of the companion now, only they can cancel it
+			return ErrGroupSeatClaimed
+		case models.GroupSeatCancelled:
+			return repositories.ErrGroupSeatNotFound
+		}
+
+		if err := u.checkCancellationCutoff(ctx, group.WorkshopID); err != nil {
+			return err
+		}
+		return u.cancelGroupSeats(ctx, group.WorkshopID, groupID, []int64{seatID})
+	})
+}
+
+func (u *bookingUsecaseImpl) CancelGroupBooking(ctx context.Context, leaderID int64, groupID int64) error {
+	ctx, span := tracer.Start(ctx, "BookingUsecase.CancelGroupBooking")
+	defer span.End()
+
+	return u.transactioner.Transaction(ctx, func(ctx context.Context) error {
+		group, err := u.groupBookingRepo.GetGroupForUpdate(ctx, groupID, leaderID)
+		if err != nil {
+			return err
+		}
+		if err := u.checkCancellationCutoff(ctx, group.WorkshopID); err != nil {
+			return err
+		}
+		return u.cancelGroupSeats(ctx, group.WorkshopID, groupID, nil)
+	})
+}
+
+// cancelGroupSeats cancels the reserved seats of a group, all of them when seatIDs is empty, and offers each
+// freed seat to the waitlist. It must be called inside a transaction.
+func (u *bookingUsecaseImpl) cancelGroupSeats(ctx context.Context, workshopID int64, groupID int64, seatIDs []int64) error {
+	n, err := u.groupBookingRepo.CancelReservedSeats(ctx, groupID, seatIDs)
+	if err != nil {
+		return err
+	}
+	for range n {
+		if err := u.workshopRepo.DecrementRegisteredCount(ctx, workshopID); err != nil {
+			return err
+		}
+		if err := u.promoteFromWaitlist(ctx, workshopID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *bookingUsecaseImpl) ClaimGroupSeat(ctx context.Context, userID int64, userEmail string, claimCode string) error {
+	ctx, span := tracer.Start(ctx, "BookingUsecase.ClaimGroupSeat")
+	defer span.End()
+
+	user, err := u.userRepo.GetUserByEmail(ctx, userEmail, []string{"participant_type"})
+	if err != nil {
+		return err
+	}
+
 	return u.transactioner.Transaction(ctx, func(ctx context.Context) error {
-		err := u.bookingRepo.CancelBooking(ctx, userID, workshopID)
+		seat, err := u.groupBookingRepo.GetSeatByCodeForUpdate(ctx, claimCode)
 		if err != nil {
 			return err
 		}
-		return u.workshopRepo.DecrementRegisteredCount(ctx, workshopID)
+		switch seat.Status {
+		case models.GroupSeatClaimed:
+			return ErrGroupSeatClaimed
+		case models.GroupSeatCancelled:
+			return repositories.ErrGroupSeatNotFound
+		}
+
+		// The seat is already held, so only the end of the workshop matters and not the booking window
+		workshop, err := u.workshopRepo.GetWorkshopById(ctx, seat.WorkshopID, bookingWorkshopFields)
+		if err != nil {
+			return err
+		}
+		end, err := workshopDateTime(*workshop.EventDate, *workshop.EndTime)
+		if err != nil {
+			return err
+		}
+		if time.Now().After(end) {
+			return ErrBookingClosed
+		}
+		if err := u.checkBookingEligibility(ctx, userID, user.ParticipantType, workshop); err != nil {
+			return err
+		}
+
+		booking := &models.Booking{
+			UserID:          userID,
+			WorkshopID:      seat.WorkshopID,
+			Status:          models.StatusConfirmed,
+			CreatedAt:       time.Now(),
+			ParticipantType: user.ParticipantType,
+		}
+		if err := u.bookingRepo.CreateBooking(ctx, booking); err != nil {
+			return err
+		}
+		if err := u.groupBookingRepo.ClaimSeat(ctx, seat.ID, booking.ID); err != nil {
+			return err
+		}
+		// The seat was held for the companion type, take it again as the claimer so the seat quotas still hold
+		if user.ParticipantType != seat.ParticipantType {
+			if err := u.workshopRepo.DecrementRegisteredCount(ctx, seat.WorkshopID); err != nil {
+				return err
+			}
+			if err := u.workshopRepo.IncrementRegisteredCount(ctx, seat.WorkshopID, user.ParticipantType, false); err != nil {
+				return err
+			}
+		}
+		if err := u.notificationRepo.EnqueueNotification(ctx, models.NotificationBookingConfirmed, booking.ID); err != nil {
+			return err
+		}
+		if err := u.eventRepo.AppendEvent(ctx, models.EventBookingCreated, models.BookingEventPayload{
+			BookingID:  booking.ID,
+			UserID:     userID,
+			WorkshopID: seat.WorkshopID,
+		}); err != nil {
+			return err
+		}
+		if err := u.waitlistRepo.DeleteWaitlistEntry(ctx, userID, seat.WorkshopID); err != nil && err != repositories.ErrWaitlistEntryNotFound {
+			return err
+		}
+		return nil
+	})
+}
+
+func (u *bookingUsecaseImpl) JoinWaitlist(ctx context.Context, userID int64, userEmail string, workshopID int64) error {
+	ctx, span := tracer.Start(ctx, "BookingUsecase.JoinWaitlist")
+	defer span.End()
+
+	workshop, err := u.workshopRepo.GetWorkshopById(ctx, workshopID, bookingWorkshopFields)
+	if err != nil {
+		return err
+	}
+	if err := checkBookingWindow(workshop); err != nil {
+		return err
+	}
+	if *workshop.RegisteredCount < *workshop.TotalSeats {
+		return ErrWorkshopNotFull
+	}
+
+	user, err := u.userRepo.GetUserByEmail(ctx, userEmail, []string{"participant_type"})
+	if err != nil {
+		return err
+	}
+	if err := u.checkBookingEligibility(ctx, userID, user.ParticipantType, workshop); err != nil {
+		return err
+	}
+
+	return u.waitlistRepo.CreateWaitlistEntry(ctx, &models.WaitlistEntry{
+		UserID:     userID,
+		WorkshopID: workshopID,
+		CreatedAt:  time.Now(),
 	})
 }
 
+func (u *bookingUsecaseImpl) LeaveWaitlist(ctx context.Context, userID int64, workshopID int64) error {
+	ctx, span := tracer.Start(ctx, "BookingUsecase.LeaveWaitlist")
+	defer span.End()
+
+	return u.waitlistRepo.DeleteWaitlistEntry(ctx, userID, workshopID)
+}
+
+func (u *bookingUsecaseImpl) GetMyWaitlist(ctx context.Context, userID int64) ([]models.WaitlistEntryWithWorkshop, error) {
+	ctx, span := tracer.Start(ctx, "BookingUsecase.GetMyWaitlist")
+	defer span.End()
+
+	entries, err := u.waitlistRepo.GetUserWaitlistEntries(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort by event_date then start_time
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].EventDate != entries[j].EventDate {
+			return entries[i].EventDate < entries[j].EventDate
+		}
+		return entries[i].StartTime.Before(entries[j].StartTime)
+	})
+
+	return entries, nil
+}
+
 func (u *bookingUsecaseImpl) GetMyBookings(ctx context.Context, userID int64) ([]models.BookingWithWorkshop, error) {
+	ctx, span := tracer.Start(ctx, "BookingUsecase.GetMyBookings")
+	defer span.End()
+
 	bookings, err := u.bookingRepo.GetUserBookings(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -138,5 +830,8 @@ func (u *bookingUsecaseImpl) GetMyBookings(ctx context.Context, userID int64) ([
 }
 
 func (u *bookingUsecaseImpl) UpdateBookingStatus(ctx context.Context, bookingID int64, status models.Status) error {
+	ctx, span := tracer.Start(ctx, "BookingUsecase.UpdateBookingStatus")
+	defer span.End()
+
 	return u.bookingRepo.UpdateBookingStatus(ctx, bookingID, status)
 }
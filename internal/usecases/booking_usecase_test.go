@@ -0,0 +1,394 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/config"
+)
+
+func TestCheckBookingPolicy(t *testing.T) {
+	booking := func(status models.Status, end time.Time) models.BookingWithWorkshop {
+		return models.BookingWithWorkshop{
+			Status:    status,
+			EventDate: end.Format(time.RFC3339),
+			EndTime:   end,
+		}
+	}
+	ended := time.Now().Add(-2 * time.Hour)
+	upcoming := time.Now().Add(48 * time.Hour)
+	policy := config.BookingPolicy{MaxConfirmedBookings: 2, MaxAbsences: 2}
+
+	tests := []struct {
+		name     string
+		bookings []models.BookingWithWorkshop
+		want     error
+	}{
+		{
+			name: "no bookings",
+		},
+		{
+			name:     "absences below the limit",
+			bookings: []models.BookingWithWorkshop{booking(models.StatusAbsent, ended), booking(models.StatusAttended, ended)},
+		},
+		{
+			name:     "absences reach the limit",
+			bookings: []models.BookingWithWorkshop{booking(models.StatusAbsent, ended), booking(models.StatusAbsent, ended)},
+			want:     ErrBookingSuspended,
+		},
+		{
+			// The sweeper has not marked the ended booking Absent yet
+			name:     "unswept no-show counts as an absence",
+			bookings: []models.BookingWithWorkshop{booking(models.StatusAbsent, ended), booking(models.StatusConfirmed, ended)},
+			want:     ErrBookingSuspended,
+		},
+		{
+			name:     "upcoming bookings reach the cap",
+			bookings: []models.BookingWithWorkshop{booking(models.StatusConfirmed, upcoming), booking(models.StatusConfirmed, upcoming)},
+			want:     ErrBookingLimitReached,
+		},
+		{
+			name:     "cancelled bookings do not count",
+			bookings: []models.BookingWithWorkshop{booking(models.StatusConfirmed, upcoming), booking(models.StatusCancelled, upcoming)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &bookingUsecaseImpl{policy: policy}
+			if err := u.checkBookingPolicy(tt.bookings); err != tt.want {
+				t.Errorf("checkBookingPolicy() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+var errGuardsPassed = errors.New("guards passed")
+
+// fakeWindowWorkshopRepo serves one workshop and how many of its seats are released so far.
+type fakeWindowWorkshopRepo struct {
+	repositories.WorkshopRepo
+	workshop models.WorkshopOptional
+	released int
+}
+
+func (r fakeWindowWorkshopRepo) GetWorkshopById(ctx context.Context, id int64, fields []string) (*models.WorkshopOptional, error) {
+	return &r.workshop, nil
+}
+
+func (r fakeWindowWorkshopRepo) GetReleasedSeats(ctx context.Context, id int64) (int, error) {
+	return r.released, nil
+}
+
+// fakeGuardsPassedUserRepo is reached once the workshop guards let a booking through.
+type fakeGuardsPassedUserRepo struct {
+	repositories.UserRepo
+}
+
+func (fakeGuardsPassedUserRepo) GetUserByEmail(ctx context.Context, email string, fields []string) (*models.User, error) {
+	return nil, errGuardsPassed
+}
+
+func TestBookWorkshopWindowGuards(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		opensAt    *time.Time
+		closesAt   *time.Time
+		registered int
+		released   int
+		want       error
+	}{
+		{name: "no window", released: 10, want: errGuardsPassed},
+		{name: "inside the window", opensAt: &past, closesAt: &future, released: 10, want: errGuardsPassed},
+		{name: "before opening", opensAt: &future, released: 10, want: ErrBookingNotOpen},
+		{name: "after closing", closesAt: &past, released: 10, want: ErrBookingClosed},
+		{name: "every seat taken", registered: 10, released: 10, want: repositories.ErrWorkshopFull},
+		{name: "released seats taken", registered: 4, released: 4, want: ErrSeatsNotReleased},
+		{name: "seats left in the current release", registered: 3, released: 4, want: errGuardsPassed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, total, registered := int64(1), 10, tt.registered
+			repo := fakeWindowWorkshopRepo{
+				workshop: models.WorkshopOptional{
+					ID:              &id,
+					TotalSeats:      &total,
+					RegisteredCount: &registered,
+					BookingOpensAt:  tt.opensAt,
+					BookingClosesAt: tt.closesAt,
+				},
+				released: tt.released,
+			}
+			u := NewBookingUsecase(nil, repo, fakeGuardsPassedUserRepo{}, nil, nil, nil, nil, nil, config.BookingPolicy{})
+
+			if err := u.BookWorkshop(context.Background(), 1, "student@example.com", id); err != tt.want {
+				t.Errorf("BookWorkshop() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// fakeQuotaWorkshopRepo admits students and outside students, and keeps its last free seat
+// reserved for outside students. Its booking window has closed.
+type fakeQuotaWorkshopRepo struct {
+	repositories.WorkshopRepo
+	workshop models.WorkshopOptional
+}
+
+func (r fakeQuotaWorkshopRepo) GetWorkshopById(ctx context.Context, id int64, fields []string) (*models.WorkshopOptional, error) {
+	return &r.workshop, nil
+}
+
+func (r fakeQuotaWorkshopRepo) GetParticipantRules(ctx context.Context, workshopID int64) ([]models.ParticipantRule, error) {
+	reserved := 20
+	return []models.ParticipantRule{
+		{WorkshopID: workshopID, ParticipantType: models.ParticipantTypeStudent},
+		{WorkshopID: workshopID, ParticipantType: models.ParticipantTypeOutsideStudent, ReservedPercent: &reserved},
+	}, nil
+}
+
+func (r fakeQuotaWorkshopRepo) IncrementRegisteredCount(ctx context.Context, workshopID int64, participantType models.ParticipantType, enforceWindow bool) error {
+	if enforceWindow {
+		return repositories.ErrWorkshopFull
+	}
+	if participantType != models.ParticipantTypeOutsideStudent {
+		return repositories.ErrSeatsReserved
+	}
+	return nil
+}
+
+type fakeQuotaUserRepo struct {
+	repositories.UserRepo
+	types map[int64]models.ParticipantType
+}
+
+func (r fakeQuotaUserRepo) GetUserByID(ctx context.Context, id int64, fields []string) (*models.User, error) {
+	return &models.User{ID: id, ParticipantType: r.types[id]}, nil
+}
+
+type fakeWaitlistRepo struct {
+	repositories.WaitlistRepo
+	entries []models.WaitlistEntry
+	deleted []int64
+}
+
+func (r *fakeWaitlistRepo) GetWorkshopWaitlist(ctx context.Context, workshopID int64) ([]models.WaitlistEntry, error) {
+	return r.entries, nil
+}
+
+func (r *fakeWaitlistRepo) DeleteWaitlistEntry(ctx context.Context, userID int64, workshopID int64) error {
+	r.deleted = append(r.deleted, userID)
+	return nil
+}
+
+type fakeCreateBookingRepo struct {
+	repositories.BookingRepo
+	created []models.Booking
+}
+
+func (r *fakeCreateBookingRepo) GetUserBookings(ctx context.Context, userID int64) ([]models.BookingWithWorkshop, error) {
+	return nil, nil
+}
+
+func (r *fakeCreateBookingRepo) CreateBooking(ctx context.Context, booking *models.Booking) error {
+	booking.ID = int64(len(r.created) + 1)
+	r.created = append(r.created, *booking)
+	return nil
+}
+
+type fakeNotificationRepo struct {
+	repositories.NotificationRepo
+}
+
+func (fakeNotificationRepo) EnqueueNotification(ctx context.Context, kind models.NotificationKind, bookingID int64) error {
+	return nil
+}
+
+type fakeEventRepo struct {
+	repositories.EventRepo
+}
+
+func (fakeEventRepo) AppendEvent(ctx context.Context, eventType models.EventType, payload any) error {
+	return nil
+}
+
+func TestPromoteFromWaitlistQuota(t *testing.T) {
+	id := int64(1)
+	start, end := time.Date(0, 1, 1, 10, 0, 0, 0, time.UTC), time.Date(0, 1, 1, 12, 0, 0, 0, time.UTC)
+	eventDate := time.Now().Add(48 * time.Hour).Format(time.RFC3339)
+	closed := time.Now().Add(-time.Hour)
+	workshopRepo := fakeQuotaWorkshopRepo{workshop: models.WorkshopOptional{
+		ID:              &id,
+		EventDate:       &eventDate,
+		StartTime:       &start,
+		EndTime:         &end,
+		BookingClosesAt: &closed,
+	}}
+	userRepo := fakeQuotaUserRepo{types: map[int64]models.ParticipantType{
+		10: models.ParticipantTypeIntania,
+		11: models.ParticipantTypeStudent,
+		12: models.ParticipantTypeOutsideStudent,
+	}}
+	waitlistRepo := &fakeWaitlistRepo{entries: []models.WaitlistEntry{
+		{UserID: 10, WorkshopID: id},
+		{UserID: 11, WorkshopID: id},
+		{UserID: 12, WorkshopID: id},
+	}}
+	bookingRepo := &fakeCreateBookingRepo{}
+	u := &bookingUsecaseImpl{
+		bookingRepo:      bookingRepo,
+		workshopRepo:     workshopRepo,
+		userRepo:         userRepo,
+		waitlistRepo:     waitlistRepo,
+		notificationRepo: fakeNotificationRepo{},
+		eventRepo:        fakeEventRepo{},
+	}
+
+	if err := u.promoteFromWaitlist(context.Background(), id); err != nil {
+		t.Fatalf("promoteFromWaitlist() error = %v", err)
+	}
+
+	// The intania entry is not admitted and the student entry cannot take the reserved seat,
+	// both stay on the waitlist while the outside student is promoted after the window closed.
+	if len(bookingRepo.created) != 1 {
+		t.Fatalf("created %d bookings, want 1", len(bookingRepo.created))
+	}
+	if got := bookingRepo.created[0]; got.UserID != 12 || got.ParticipantType != models.ParticipantTypeOutsideStudent {
+		t.Errorf("promoted user %d as %q, want user 12 as %q", got.UserID, got.ParticipantType, models.ParticipantTypeOutsideStudent)
+	}
+	if len(waitlistRepo.deleted) != 1 || waitlistRepo.deleted[0] != 12 {
+		t.Errorf("deleted waitlist entries of %v, want [12]", waitlistRepo.deleted)
+	}
+}
+
+type fakeTransactioner struct{}
+
+func (fakeTransactioner) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeGroupLeaderRepo struct {
+	repositories.UserRepo
+}
+
+func (fakeGroupLeaderRepo) GetUserByEmail(ctx context.Context, email string, fields []string) (*models.User, error) {
+	return &models.User{ParticipantType: models.ParticipantTypeTeacher}, nil
+}
+
+// fakeSeatGuardRepo has free seats and answers every seat taken with incrementErr.
+type fakeSeatGuardRepo struct {
+	repositories.WorkshopRepo
+	incrementErr error
+}
+
+func (fakeSeatGuardRepo) GetWorkshopById(ctx context.Context, id int64, fields []string) (*models.WorkshopOptional, error) {
+	registered, total := 0, 10
+	name, date, location := "Robotics", "2026-03-28", "Building 3"
+	start := time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	return &models.WorkshopOptional{ID: &id, Name: &name, EventDate: &date, StartTime: &start, EndTime: &end,
+		Location: &location, RegisteredCount: &registered, TotalSeats: &total}, nil
+}
+
+func (fakeSeatGuardRepo) GetParticipantRules(ctx context.Context, workshopID int64) ([]models.ParticipantRule, error) {
+	return nil, nil
+}
+
+func (fakeSeatGuardRepo) GetReleasedSeats(ctx context.Context, id int64) (int, error) {
+	return 10, nil
+}
+
+func (fakeSeatGuardRepo) GetWorkshopForUpdate(ctx context.Context, id int64) (*models.Workshop, error) {
+	return &models.Workshop{ID: id, TotalSeats: 10}, nil
+}
+
+func (r fakeSeatGuardRepo) IncrementRegisteredCount(ctx context.Context, workshopID int64, participantType models.ParticipantType, enforceWindow bool) error {
+	return r.incrementErr
+}
+
+type fakeGroupBookingRepo struct {
+	repositories.GroupBookingRepo
+	heldSeats int
+}
+
+func (fakeGroupBookingRepo) CreateSeats(ctx context.Context, seats []models.GroupSeat) error {
+	return nil
+}
+
+func (r fakeGroupBookingRepo) CountLeaderSeats(ctx context.Context, leaderID int64, workshopID int64) (int, error) {
+	return r.heldSeats, nil
+}
+
+type fakeLeaderBookingRepo struct {
+	repositories.BookingRepo
+	bookings []models.BookingWithWorkshop
+}
+
+func (r fakeLeaderBookingRepo) GetUserBookings(ctx context.Context, userID int64) ([]models.BookingWithWorkshop, error) {
+	return r.bookings, nil
+}
+
+func (fakeGroupBookingRepo) CreateGroup(ctx context.Context, group *models.BookingGroup) error {
+	group.ID = 1
+	return nil
+}
+
+func TestCreateGroupBookingSeatGuardErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		incrementErr error
+		want         error
+	}{
+		{name: "seats reserved for other types", incrementErr: repositories.ErrSeatsReserved, want: repositories.ErrSeatsReserved},
+		{name: "workshop full", incrementErr: repositories.ErrWorkshopFull, want: ErrNotEnoughSeats},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewBookingUsecase(fakeLeaderBookingRepo{}, fakeSeatGuardRepo{incrementErr: tt.incrementErr}, fakeGroupLeaderRepo{}, nil,
+				fakeGroupBookingRepo{}, nil, nil, fakeTransactioner{}, config.BookingPolicy{})
+
+			_, err := u.CreateGroupBooking(context.Background(), 1, "leader@example.com", 1, []string{"A", "B"})
+			if err != tt.want {
+				t.Fatalf("CreateGroupBooking() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateGroupBookingLeaderLimits(t *testing.T) {
+	absence := models.BookingWithWorkshop{WorkshopID: 2, Status: models.StatusAbsent}
+	policy := config.BookingPolicy{MaxGroupSize: 5, MaxAbsences: 2}
+
+	tests := []struct {
+		name      string
+		heldSeats int
+		bookings  []models.BookingWithWorkshop
+		want      error
+	}{
+		{name: "first group", want: nil},
+		{name: "group within the cap of the leader", heldSeats: 3, want: nil},
+		{name: "earlier groups count towards the cap", heldSeats: 4, want: ErrGroupSeatLimitReached},
+		{name: "leader suspended after absences", bookings: []models.BookingWithWorkshop{absence, absence}, want: ErrBookingSuspended},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewBookingUsecase(fakeLeaderBookingRepo{bookings: tt.bookings}, fakeSeatGuardRepo{}, fakeGroupLeaderRepo{}, nil,
+				fakeGroupBookingRepo{heldSeats: tt.heldSeats}, nil, nil, fakeTransactioner{}, policy)
+
+			_, err := u.CreateGroupBooking(context.Background(), 1, "leader@example.com", 1, []string{"A", "B"})
+			if err != tt.want {
+				t.Fatalf("CreateGroupBooking() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/myValidator"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidBoothCheckInCode   = errors.New("booth check-in code must be a uuid")
+	ErrDuplicateBoothCheckInCode = errors.New("duplicate booth check-in code")
+	ErrEmptyImport               = errors.New("nothing to import")
+)
+
+// ImportItemError reports which item of a bulk import was rejected.
+type ImportItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *ImportItemError) Error() string {
+	return fmt.Sprintf("item %d: %s", e.Index, e.Err)
+}
+
+func (e *ImportItemError) Unwrap() error {
+	return e.Err
+}
+
+type BoothUsecase interface {
+	ListBooths(ctx context.Context, includeArchived bool) ([]*models.Booth, error)
+	CreateBooth(ctx context.Context, booth *models.Booth) error
+	ImportBooths(ctx context.Context, booths []*models.Booth) error
+	UpdateBooth(ctx context.Context, boothId int64, update *models.BoothOptional) (*models.Booth, error)
+	ArchiveBooth(ctx context.Context, boothId int64) error
+}
+
+type boothUsecaseImpl struct {
+	boothRepo     repositories.BoothRepo
+	transactioner baserepo.Transactioner
+}
+
+func NewBoothUsecase(boothRepo repositories.BoothRepo, transactioner baserepo.Transactioner) BoothUsecase {
+	return &boothUsecaseImpl{
+		boothRepo:     boothRepo,
+		transactioner: transactioner,
+	}
+}
+
+func (u *boothUsecaseImpl) ListBooths(ctx context.Context, includeArchived bool) ([]*models.Booth, error) {
+	ctx, span := tracer.Start(ctx, "BoothUsecase.ListBooths")
+	defer span.End()
+
+	return u.boothRepo.ListBooths(ctx, includeArchived)
+}
+
+func (u *boothUsecaseImpl) CreateBooth(ctx context.Context, booth *models.Booth) error {
+	ctx, span := tracer.Start(ctx, "BoothUsecase.CreateBooth")
+	defer span.End()
+
+	return u.ImportBooths(ctx, []*models.Booth{booth})
+}
+
+// ImportBooths inserts all booths or none of them. Booths without a check-in code get a new one,
+// a given code is kept so that QR codes which are already printed stay valid.
+func (u *boothUsecaseImpl) ImportBooths(ctx context.Context, booths []*models.Booth) error {
+	ctx, span := tracer.Start(ctx, "BoothUsecase.ImportBooths")
+	defer span.End()
+
+	if len(booths) == 0 {
+		return ErrEmptyImport
+	}
+
+	seenCodes := make(map[string]bool, len(booths))
+	for i, booth := range booths {
+		if err := validateBooth(booth); err != nil {
+			return &ImportItemError{Index: i, Err: err}
+		}
+
+		if booth.CheckInCode == "" {
+			booth.CheckInCode = uuid.NewString()
+		}
+		if seenCodes[booth.CheckInCode] {
+			return &ImportItemError{Index: i, Err: ErrDuplicateBoothCheckInCode}
+		}
+		seenCodes[booth.CheckInCode] = true
+	}
+
+	return u.boothRepo.CreateBooths(ctx, booths)
+}
+
+func (u *boothUsecaseImpl) UpdateBooth(ctx context.Context, boothId int64, update *models.BoothOptional) (*models.Booth, error) {
+	ctx, span := tracer.Start(ctx, "BoothUsecase.UpdateBooth")
+	defer span.End()
+
+	if update.Name == nil && update.Category == nil && update.CheckInCode == nil {
+		return nil, ErrNothingToUpdate
+	}
+	if update.Category != nil {
+		if err := myValidator.ValidateBoothCategory(string(*update.Category)); err != nil {
+			return nil, err
+		}
+	}
+	if update.CheckInCode != nil {
+		if err := uuid.Validate(*update.CheckInCode); err != nil {
+			return nil, ErrInvalidBoothCheckInCode
+		}
+	}
+
+	var booth *models.Booth
+	err := u.transactioner.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.boothRepo.UpdateBooth(ctx, boothId, update); err != nil {
+			return err
+		}
+
+		var err error
+		booth, err = u.boothRepo.GetBoothByID(ctx, boothId)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return booth, nil
+}
+
+func (u *boothUsecaseImpl) ArchiveBooth(ctx context.Context, boothId int64) error {
+	ctx, span := tracer.Start(ctx, "BoothUsecase.ArchiveBooth")
+	defer span.End()
+
+	return u.boothRepo.ArchiveBooth(ctx, boothId)
+}
+
+func validateBooth(booth *models.Booth) error {
+	if err := myValidator.ValidateBoothCategory(string(booth.Category)); err != nil {
+		return err
+	}
+	if booth.CheckInCode != "" {
+		if err := uuid.Validate(booth.CheckInCode); err != nil {
+			return ErrInvalidBoothCheckInCode
+		}
+	}
+	return nil
+}
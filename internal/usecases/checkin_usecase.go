@@ -3,36 +3,64 @@ package usecases
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/config"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/jwt"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/utils"
+	gojwt "github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
 var (
-	ErrInvalidCodeFormat = errors.New("invalid code format")
-	ErrAlreadyAttended   = errors.New("already attended")
+	ErrInvalidCodeFormat     = errors.New("invalid code format")
+	ErrAlreadyAttended       = errors.New("already attended")
+	ErrInvalidAttendeePass   = errors.New("invalid attendee pass")
+	ErrCheckInTargetRequired = errors.New("exactly one of workshop or booth must be given")
+	ErrInvalidBookingTicket  = errors.New("invalid booking ticket")
+	ErrTicketWrongWorkshop   = errors.New("booking ticket is for another workshop")
+	ErrWorkshopEnded         = errors.New("workshop already ended")
+	ErrStaffNotAssigned      = errors.New("staff not assigned to this workshop or booth")
 )
 
 type CheckInUsecase interface {
 	CheckIn(ctx context.Context, email string, code string) (CheckInOutput, error)
+	IssueAttendeePass(ctx context.Context, email string) (AttendeePass, error)
+	StaffCheckIn(ctx context.Context, staff StaffMember, passToken string, target StaffCheckInTarget) (StaffCheckInOutput, error)
+	IssueBookingTicket(ctx context.Context, email string, bookingID int64) (BookingTicket, error)
+	VerifyBookingTicket(ctx context.Context, staff StaffMember, ticketToken string, workshopID int64) (StaffCheckInOutput, error)
 }
 
 type checkInUsecaseImpl struct {
-	bookingRepo repositories.BookingRepo
-	boothRepo   repositories.BoothRepo
-	userRepo    repositories.UserRepo
+	bookingRepo   repositories.BookingRepo
+	boothRepo     repositories.BoothRepo
+	userRepo      repositories.UserRepo
+	eventRepo     repositories.EventRepo
+	staffRepo     repositories.StaffRepo
+	transactioner baserepo.Transactioner
+	tokenCfg      config.Token
 }
 
 func NewCheckInUsecase(
 	bookingRepo repositories.BookingRepo,
 	boothRepo repositories.BoothRepo,
 	userRepo repositories.UserRepo,
+	eventRepo repositories.EventRepo,
+	staffRepo repositories.StaffRepo,
+	transactioner baserepo.Transactioner,
+	tokenCfg config.Token,
 ) CheckInUsecase {
 	return &checkInUsecaseImpl{
-		bookingRepo: bookingRepo,
-		boothRepo:   boothRepo,
-		userRepo:    userRepo,
+		bookingRepo:   bookingRepo,
+		boothRepo:     boothRepo,
+		userRepo:      userRepo,
+		eventRepo:     eventRepo,
+		staffRepo:     staffRepo,
+		transactioner: transactioner,
+		tokenCfg:      tokenCfg,
 	}
 }
 
@@ -42,6 +70,11 @@ const (
 	PrefixLength   = 2
 )
 
+const (
+	attendeePassType  = "attendee_pass"
+	bookingTicketType = "booking_ticket"
+)
+
 type CheckInOutput struct {
 	Type     string
 	ID       int64
@@ -49,7 +82,42 @@ type CheckInOutput struct {
 	Category models.BoothCategory
 }
 
+type AttendeePass struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// BookingTicket is valid until the workshop ends.
+type BookingTicket struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// StaffCheckInTarget is the workshop or booth the staff member is checking the attendee into.
+// Exactly one of the fields must be set.
+type StaffCheckInTarget struct {
+	WorkshopID *int64
+	BoothID    *int64
+}
+
+// StaffMember is the staff member scanning. Admins may check attendees in anywhere, other staff only at
+// the workshops and booths they are assigned to.
+type StaffMember struct {
+	Email string
+	Admin bool
+}
+
+type StaffCheckInOutput struct {
+	CheckInOutput
+	UserID    int64
+	FirstName string
+	LastName  string
+}
+
 func (u *checkInUsecaseImpl) CheckIn(ctx context.Context, email string, code string) (CheckInOutput, error) {
+	ctx, span := tracer.Start(ctx, "CheckInUsecase.CheckIn")
+	defer span.End()
+
 	if len(code) <= PrefixLength {
 		return CheckInOutput{}, ErrInvalidCodeFormat
 	}
@@ -77,6 +145,10 @@ func (u *checkInUsecaseImpl) handleWorkshopCheckIn(ctx context.Context, email st
 		return CheckInOutput{}, err
 	}
 
+	return u.attendWorkshop(ctx, bookingData)
+}
+
+func (u *checkInUsecaseImpl) attendWorkshop(ctx context.Context, bookingData models.BookingData) (CheckInOutput, error) {
 	if bookingData.Status != models.StatusConfirmed {
 		if bookingData.Status == models.StatusAttended {
 			return CheckInOutput{}, ErrAlreadyAttended
@@ -84,16 +156,23 @@ func (u *checkInUsecaseImpl) handleWorkshopCheckIn(ctx context.Context, email st
 		return CheckInOutput{}, repositories.ErrInvalidBookingStatus
 	}
 
-	if err := u.bookingRepo.AttendBooking(ctx, bookingData.ID); err != nil {
-		return CheckInOutput{}, err
-	}
-
-	return CheckInOutput{
+	result := CheckInOutput{
 		Type:     "workshop",
 		ID:       bookingData.WorkshopID,
 		Name:     bookingData.WorkshopName,
 		Category: models.BoothCategory(bookingData.WorkshopCategory),
-	}, nil
+	}
+	err := u.transactioner.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.bookingRepo.AttendBooking(ctx, bookingData.ID); err != nil {
+			return err
+		}
+		return u.eventRepo.AppendEvent(ctx, models.EventWorkshopAttended, checkInEventPayload(bookingData.UserID, result, bookingData.ID))
+	})
+	if err != nil {
+		return CheckInOutput{}, err
+	}
+
+	return result, nil
 }
 
 func (u *checkInUsecaseImpl) handleBoothCheckIn(ctx context.Context, email string, checkInCode string) (CheckInOutput, error) {
@@ -108,14 +187,274 @@ func (u *checkInUsecaseImpl) handleBoothCheckIn(ctx context.Context, email strin
 		return CheckInOutput{}, err
 	}
 
-	if err := u.boothRepo.CreateBoothCheckIn(ctx, userID, booth.ID); err != nil {
-		return CheckInOutput{}, err
-	}
+	return u.checkInBooth(ctx, userID, booth)
+}
 
-	return CheckInOutput{
+func (u *checkInUsecaseImpl) checkInBooth(ctx context.Context, userID int64, booth *models.Booth) (CheckInOutput, error) {
+	result := CheckInOutput{
 		Type:     "booth",
 		ID:       booth.ID,
 		Name:     booth.Name,
 		Category: booth.Category,
+	}
+	err := u.transactioner.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.boothRepo.CreateBoothCheckIn(ctx, userID, booth.ID); err != nil {
+			return err
+		}
+		return u.eventRepo.AppendEvent(ctx, models.EventBoothCheckedIn, checkInEventPayload(userID, result, 0))
+	})
+	if err != nil {
+		return CheckInOutput{}, err
+	}
+
+	return result, nil
+}
+
+func checkInEventPayload(userID int64, result CheckInOutput, bookingID int64) models.CheckInEventPayload {
+	return models.CheckInEventPayload{
+		UserID:    userID,
+		Type:      result.Type,
+		ID:        result.ID,
+		Name:      result.Name,
+		Category:  result.Category,
+		BookingID: bookingID,
+	}
+}
+
+func (u *checkInUsecaseImpl) IssueAttendeePass(ctx context.Context, email string) (AttendeePass, error) {
+	ctx, span := tracer.Start(ctx, "CheckInUsecase.IssueAttendeePass")
+	defer span.End()
+
+	user, err := u.userRepo.GetUserByEmail(ctx, email, []string{"id"})
+	if err != nil {
+		return AttendeePass{}, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(u.tokenCfg.AttendeePassTTL)
+	token, err := jwt.GenerateToken([]byte(u.tokenCfg.SecretKey), gojwt.MapClaims{
+		"typ": attendeePassType,
+		"uid": user.ID,
+		"iat": now.Unix(),
+		"exp": expiresAt.Unix(),
+	})
+	if err != nil {
+		return AttendeePass{}, err
+	}
+
+	return AttendeePass{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+func (u *checkInUsecaseImpl) StaffCheckIn(ctx context.Context, staff StaffMember, passToken string, target StaffCheckInTarget) (StaffCheckInOutput, error) {
+	ctx, span := tracer.Start(ctx, "CheckInUsecase.StaffCheckIn")
+	defer span.End()
+
+	if (target.WorkshopID == nil) == (target.BoothID == nil) {
+		return StaffCheckInOutput{}, ErrCheckInTargetRequired
+	}
+	if err := u.checkStaffAssignment(ctx, staff, target); err != nil {
+		return StaffCheckInOutput{}, err
+	}
+
+	userID, err := u.parseAttendeePass(passToken)
+	if err != nil {
+		return StaffCheckInOutput{}, err
+	}
+
+	user, err := u.userRepo.GetUserByID(ctx, userID, []string{"id", "first_name", "last_name"})
+	if err != nil {
+		return StaffCheckInOutput{}, err
+	}
+
+	var result CheckInOutput
+	if target.WorkshopID != nil {
+		bookingData, err := u.bookingRepo.GetUserBookingData(ctx, user.ID, *target.WorkshopID)
+		if err != nil {
+			return StaffCheckInOutput{}, err
+		}
+		result, err = u.attendWorkshop(ctx, bookingData)
+		if err != nil {
+			return StaffCheckInOutput{}, err
+		}
+	} else {
+		booth, err := u.boothRepo.GetBoothByID(ctx, *target.BoothID)
+		if err != nil {
+			return StaffCheckInOutput{}, err
+		}
+		if booth.ArchivedAt != nil {
+			return StaffCheckInOutput{}, repositories.ErrBoothNotFound
+		}
+		result, err = u.checkInBooth(ctx, user.ID, booth)
+		if err != nil {
+			return StaffCheckInOutput{}, err
+		}
+	}
+
+	return StaffCheckInOutput{
+		CheckInOutput: result,
+		UserID:        user.ID,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
 	}, nil
 }
+
+func (u *checkInUsecaseImpl) checkStaffAssignment(ctx context.Context, staff StaffMember, target StaffCheckInTarget) error {
+	if staff.Admin {
+		return nil
+	}
+	assigned, err := u.staffRepo.IsAssigned(ctx, normalizeEmail(staff.Email), target.WorkshopID, target.BoothID)
+	if err != nil {
+		return err
+	}
+	if !assigned {
+		return ErrStaffNotAssigned
+	}
+	return nil
+}
+
+func (u *checkInUsecaseImpl) parseAttendeePass(passToken string) (int64, error) {
+	claims, err := u.parseToken(passToken, attendeePassType)
+	if err != nil {
+		return 0, ErrInvalidAttendeePass
+	}
+
+	uid, ok := int64Claim(claims, "uid")
+	if !ok {
+		return 0, ErrInvalidAttendeePass
+	}
+
+	return uid, nil
+}
+
+func (u *checkInUsecaseImpl) IssueBookingTicket(ctx context.Context, email string, bookingID int64) (BookingTicket, error) {
+	ctx, span := tracer.Start(ctx, "CheckInUsecase.IssueBookingTicket")
+	defer span.End()
+
+	user, err := u.userRepo.GetUserByEmail(ctx, email, []string{"id"})
+	if err != nil {
+		return BookingTicket{}, err
+	}
+
+	bookingData, err := u.bookingRepo.GetBookingDataByID(ctx, bookingID)
+	if err != nil {
+		return BookingTicket{}, err
+	}
+	// Do not reveal bookings of other users
+	if bookingData.UserID != user.ID {
+		return BookingTicket{}, repositories.ErrBookingNotFound
+	}
+
+	switch bookingData.Status {
+	case models.StatusConfirmed:
+	case models.StatusAttended:
+		return BookingTicket{}, ErrAlreadyAttended
+	default:
+		return BookingTicket{}, repositories.ErrInvalidBookingStatus
+	}
+
+	expiresAt, err := workshopDateTime(bookingData.EventDate, bookingData.EndTime)
+	if err != nil {
+		return BookingTicket{}, err
+	}
+	now := time.Now()
+	if !now.Before(expiresAt) {
+		return BookingTicket{}, ErrWorkshopEnded
+	}
+
+	token, err := jwt.GenerateToken([]byte(u.tokenCfg.SecretKey), gojwt.MapClaims{
+		"typ": bookingTicketType,
+		"bid": bookingData.ID,
+		"uid": bookingData.UserID,
+		"wid": bookingData.WorkshopID,
+		"iat": now.Unix(),
+		"exp": expiresAt.Unix(),
+	})
+	if err != nil {
+		return BookingTicket{}, err
+	}
+
+	return BookingTicket{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+func (u *checkInUsecaseImpl) VerifyBookingTicket(ctx context.Context, staff StaffMember, ticketToken string, workshopID int64) (StaffCheckInOutput, error) {
+	ctx, span := tracer.Start(ctx, "CheckInUsecase.VerifyBookingTicket")
+	defer span.End()
+
+	if err := u.checkStaffAssignment(ctx, staff, StaffCheckInTarget{WorkshopID: &workshopID}); err != nil {
+		return StaffCheckInOutput{}, err
+	}
+
+	claims, err := u.parseToken(ticketToken, bookingTicketType)
+	if err != nil {
+		return StaffCheckInOutput{}, ErrInvalidBookingTicket
+	}
+	bookingID, okBooking := int64Claim(claims, "bid")
+	userID, okUser := int64Claim(claims, "uid")
+	ticketWorkshopID, okWorkshop := int64Claim(claims, "wid")
+	if !okBooking || !okUser || !okWorkshop {
+		return StaffCheckInOutput{}, ErrInvalidBookingTicket
+	}
+	// a valid ticket scanned at the door of another workshop
+	if ticketWorkshopID != workshopID {
+		return StaffCheckInOutput{}, ErrTicketWrongWorkshop
+	}
+
+	bookingData, err := u.bookingRepo.GetBookingDataByID(ctx, bookingID)
+	if err != nil {
+		return StaffCheckInOutput{}, err
+	}
+	if bookingData.UserID != userID || bookingData.WorkshopID != workshopID {
+		return StaffCheckInOutput{}, ErrInvalidBookingTicket
+	}
+
+	user, err := u.userRepo.GetUserByID(ctx, userID, []string{"id", "first_name", "last_name"})
+	if err != nil {
+		return StaffCheckInOutput{}, err
+	}
+
+	result, err := u.attendWorkshop(ctx, bookingData)
+	if err != nil {
+		return StaffCheckInOutput{}, err
+	}
+
+	return StaffCheckInOutput{
+		CheckInOutput: result,
+		UserID:        user.ID,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+	}, nil
+}
+
+// parseToken verifies the signature and expiry of a token issued by this API and checks its type.
+func (u *checkInUsecaseImpl) parseToken(token string, tokenType string) (gojwt.MapClaims, error) {
+	claims, err := jwt.ParseAuthToken([]byte(u.tokenCfg.SecretKey), token)
+	if err != nil {
+		return nil, err
+	}
+
+	if typ, _ := claims["typ"].(string); typ != tokenType {
+		return nil, errors.New("unexpected token type")
+	}
+
+	return claims, nil
+}
+
+func int64Claim(claims gojwt.MapClaims, key string) (int64, bool) {
+	// JSON numbers are decoded as float64
+	value, ok := claims[key].(float64)
+	if !ok || value <= 0 {
+		return 0, false
+	}
+	return int64(value), true
+}
+
+// workshopDateTime combines a DATE column, scanned as an RFC3339 string, with a TIME column in Asia/Bangkok.
+func workshopDateTime(eventDate string, clock time.Time) (time.Time, error) {
+	date, err := time.Parse(time.RFC3339, eventDate)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	y, m, d := date.Date()
+	return time.Date(y, m, d, clock.Hour(), clock.Minute(), clock.Second(), 0, utils.BangkokLocation), nil
+}
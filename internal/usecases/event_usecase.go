@@ -0,0 +1,132 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/config"
+)
+
+const (
+	eventBatchSize = 100
+	// eventLease must be longer than the slowest subscriber takes for a whole batch
+	eventLease = 5 * time.Minute
+)
+
+// EventHandler must be idempotent, an event is delivered again when any subscriber of it fails.
+type EventHandler func(ctx context.Context, event models.DomainEvent) error
+
+// EventDispatcher delivers the events appended by usecases to in-process subscribers, at least once.
+// Events are delivered roughly in order of occurrence, but a failing event does not hold back later ones.
+type EventDispatcher interface {
+	// Subscribe must be called before Run.
+	Subscribe(name string, handler EventHandler, eventTypes ...models.EventType)
+	// Run dispatches pending events every poll interval until ctx is done.
+	Run(ctx context.Context)
+}
+
+type eventSubscription struct {
+	name    string
+	handler EventHandler
+}
+
+type eventDispatcherImpl struct {
+	eventRepo repositories.EventRepo
+	cfg       config.Events
+
+	mu            sync.RWMutex
+	subscriptions map[models.EventType][]eventSubscription
+}
+
+func NewEventDispatcher(eventRepo repositories.EventRepo, cfg config.Events) EventDispatcher {
+	return &eventDispatcherImpl{
+		eventRepo:     eventRepo,
+		cfg:           cfg,
+		subscriptions: make(map[models.EventType][]eventSubscription),
+	}
+}
+
+func (d *eventDispatcherImpl) Subscribe(name string, handler EventHandler, eventTypes ...models.EventType) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, t := range eventTypes {
+		d.subscriptions[t] = append(d.subscriptions[t], eventSubscription{name: name, handler: handler})
+	}
+}
+
+func (d *eventDispatcherImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Keep draining while full batches come back
+		for {
+			n, err := d.dispatchBatch(ctx)
+			if err != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "events: dispatch", "error", err)
+			}
+			if err != nil || n < eventBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *eventDispatcherImpl) dispatchBatch(ctx context.Context) (int, error) {
+	events, err := d.eventRepo.ClaimPendingEvents(ctx, d.cfg.MaxAttempts, eventLease, eventBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range events {
+		if dispatchErr := d.dispatch(ctx, event); dispatchErr != nil {
+			retryAt := time.Now().Add(eventBackoff(event.Attempts))
+			if err := d.eventRepo.MarkEventFailed(ctx, event.ID, dispatchErr, retryAt); err != nil {
+				return len(events), err
+			}
+			if event.Attempts+1 >= d.cfg.MaxAttempts {
+				slog.ErrorContext(ctx, "events: giving up on event", "event_id", event.ID, "event_type", event.Type, "error", dispatchErr)
+			}
+			continue
+		}
+		if err := d.eventRepo.MarkEventDispatched(ctx, event.ID); err != nil {
+			return len(events), err
+		}
+	}
+
+	return len(events), nil
+}
+
+// dispatch calls every subscriber even when an earlier one fails, so one broken subscriber
+// does not delay the others more than the retry does.
+func (d *eventDispatcherImpl) dispatch(ctx context.Context, event models.DomainEvent) error {
+	d.mu.RLock()
+	subs := d.subscriptions[event.Type]
+	d.mu.RUnlock()
+
+	var errs []error
+	for _, sub := range subs {
+		if err := sub.handler(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sub.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// eventBackoff doubles from 10 seconds up to an hour.
+func eventBackoff(attempts int) time.Duration {
+	return min(10*time.Second<<min(attempts, 9), time.Hour)
+}
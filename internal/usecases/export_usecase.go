@@ -0,0 +1,330 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/export"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/myValidator"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/utils"
+)
+
+var (
+	ErrInvalidExportKind = errors.New("invalid export kind")
+	ErrInvalidExportDate = errors.New("invalid export date, expected YYYY-MM-DD")
+)
+
+const exportPageSize = 1000
+
+type ExportUsecase interface {
+	ValidateExport(kind models.ExportKind, filter models.ExportFilter) error
+	Export(ctx context.Context, kind models.ExportKind, filter models.ExportFilter, w export.Writer) error
+}
+
+type exportUsecaseImpl struct {
+	exportRepo repositories.ExportRepo
+}
+
+func NewExportUsecase(exportRepo repositories.ExportRepo) ExportUsecase {
+	return &exportUsecaseImpl{
+		exportRepo: exportRepo,
+	}
+}
+
+// NewExportFilter builds a filter from `YYYY-MM-DD` dates in Asia/Bangkok, both ends are inclusive and may be empty.
+func NewExportFilter(from string, to string, participantType string) (models.ExportFilter, error) {
+	filter := models.ExportFilter{ParticipantType: models.ParticipantType(participantType)}
+
+	if from != "" {
+		t, err := time.ParseInLocation(time.DateOnly, from, utils.BangkokLocation)
+		if err != nil {
+			return models.ExportFilter{}, ErrInvalidExportDate
+		}
+		filter.From = &t
+	}
+	if to != "" {
+		t, err := time.ParseInLocation(time.DateOnly, to, utils.BangkokLocation)
+		if err != nil {
+			return models.ExportFilter{}, ErrInvalidExportDate
+		}
+		t = t.AddDate(0, 0, 1)
+		filter.To = &t
+	}
+
+	return filter, nil
+}
+
+// ValidateExport lets callers reject a request before they start writing the output.
+func (u *exportUsecaseImpl) ValidateExport(kind models.ExportKind, filter models.ExportFilter) error {
+	switch kind {
+	case models.ExportKindUsers, models.ExportKindBookings, models.ExportKindBoothCheckIns, models.ExportKindStampRedemptions:
+	default:
+		return ErrInvalidExportKind
+	}
+	if filter.ParticipantType != "" {
+		if err := myValidator.ValidateParticipantType(string(filter.ParticipantType)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Export writes the header and every matching row to w, it does not close w.
+func (u *exportUsecaseImpl) Export(ctx context.Context, kind models.ExportKind, filter models.ExportFilter, w export.Writer) error {
+	ctx, span := tracer.Start(ctx, "ExportUsecase.Export")
+	defer span.End()
+
+	if err := u.ValidateExport(kind, filter); err != nil {
+		return err
+	}
+
+	switch kind {
+	case models.ExportKindUsers:
+		return u.exportUsers(ctx, filter, w)
+	case models.ExportKindBookings:
+		return u.exportBookings(ctx, filter, w)
+	case models.ExportKindBoothCheckIns:
+		return u.exportBoothCheckIns(ctx, filter, w)
+	default:
+		return u.exportStampRedemptions(ctx, filter, w)
+	}
+}
+
+var userExportHeader = []string{
+	"id", "email", "first_name", "last_name", "gender", "phone_number", "participant_type",
+	"transport_mode", "is_from_bangkok", "origin_location", "attendance_dates",
+	"interested_activities", "discovery_channel", "created_at",
+}
+
+func (u *exportUsecaseImpl) exportUsers(ctx context.Context, filter models.ExportFilter, w export.Writer) error {
+	extraColumns := extraAttributeColumns()
+	if err := w.WriteRow(append(append([]string{}, userExportHeader...), extraColumns...)); err != nil {
+		return err
+	}
+
+	var afterID int64
+	for {
+		users, err := u.exportRepo.ListUsers(ctx, filter, afterID, exportPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, user := range users {
+			row := []string{
+				strconv.FormatInt(user.ID, 10),
+				user.Email,
+				user.FirstName,
+				user.LastName,
+				string(user.Gender),
+				user.PhoneNumber,
+				string(user.ParticipantType),
+				string(user.TransportMode),
+				strconv.FormatBool(user.IsFromBangkok),
+				string(user.OriginLocation),
+				strings.Join(user.AttendanceDates, ";"),
+				strings.Join(user.InterestedActivities, ";"),
+				strings.Join(user.DiscoveryChannel, ";"),
+				formatExportTime(user.CreatedAt),
+			}
+			row = append(row, flattenExtraAttributes(user.ExtraAttributes, extraColumns)...)
+			if err := w.WriteRow(row); err != nil {
+				return err
+			}
+		}
+
+		if len(users) < exportPageSize {
+			return nil
+		}
+		afterID = users[len(users)-1].ID
+	}
+}
+
+func (u *exportUsecaseImpl) exportBookings(ctx context.Context, filter models.ExportFilter, w export.Writer) error {
+	header := []string{
+		"id", "status", "created_at", "checked_in_at", "user_id", "email", "first_name", "last_name", "participant_type",
+		"workshop_id", "workshop_name", "workshop_category", "event_date", "start_time", "end_time", "location",
+	}
+	if err := w.WriteRow(header); err != nil {
+		return err
+	}
+
+	var afterID int64
+	for {
+		bookings, err := u.exportRepo.ListBookings(ctx, filter, afterID, exportPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, b := range bookings {
+			checkedInAt := ""
+			if b.CheckedInAt != nil {
+				checkedInAt = formatExportTime(*b.CheckedInAt)
+			}
+			row := []string{
+				strconv.FormatInt(b.ID, 10),
+				string(b.Status),
+				formatExportTime(b.CreatedAt),
+				checkedInAt,
+				strconv.FormatInt(b.UserID, 10),
+				b.Email,
+				b.FirstName,
+				b.LastName,
+				string(b.ParticipantType),
+				strconv.FormatInt(b.WorkshopID, 10),
+				b.WorkshopName,
+				string(b.WorkshopCategory),
+				utils.FormatDate(b.EventDate),
+				b.StartTime.Format("15:04"),
+				b.EndTime.Format("15:04"),
+				b.Location,
+			}
+			if err := w.WriteRow(row); err != nil {
+				return err
+			}
+		}
+
+		if len(bookings) < exportPageSize {
+			return nil
+		}
+		afterID = bookings[len(bookings)-1].ID
+	}
+}
+
+func (u *exportUsecaseImpl) exportBoothCheckIns(ctx context.Context, filter models.ExportFilter, w export.Writer) error {
+	header := []string{
+		"id", "checked_in_at", "user_id", "email", "first_name", "last_name", "participant_type",
+		"booth_id", "booth_name", "booth_category",
+	}
+	if err := w.WriteRow(header); err != nil {
+		return err
+	}
+
+	var afterID int64
+	for {
+		checkIns, err := u.exportRepo.ListBoothCheckIns(ctx, filter, afterID, exportPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range checkIns {
+			row := []string{
+				strconv.FormatInt(c.ID, 10),
+				formatExportTime(c.CheckedInAt),
+				strconv.FormatInt(c.UserID, 10),
+				c.Email,
+				c.FirstName,
+				c.LastName,
+				string(c.ParticipantType),
+				strconv.FormatInt(c.BoothID, 10),
+				c.BoothName,
+				string(c.BoothCategory),
+			}
+			if err := w.WriteRow(row); err != nil {
+				return err
+			}
+		}
+
+		if len(checkIns) < exportPageSize {
+			return nil
+		}
+		afterID = checkIns[len(checkIns)-1].ID
+	}
+}
+
+func (u *exportUsecaseImpl) exportStampRedemptions(ctx context.Context, filter models.ExportFilter, w export.Writer) error {
+	header := []string{"id", "stamp_type", "user_id", "email", "first_name", "last_name", "participant_type"}
+	if err := w.WriteRow(header); err != nil {
+		return err
+	}
+
+	var afterID int64
+	for {
+		redemptions, err := u.exportRepo.ListStampRedemptions(ctx, filter, afterID, exportPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range redemptions {
+			row := []string{
+				strconv.FormatInt(r.ID, 10),
+				string(r.Type),
+				strconv.FormatInt(r.UserID, 10),
+				r.Email,
+				r.FirstName,
+				r.LastName,
+				string(r.ParticipantType),
+			}
+			if err := w.WriteRow(row); err != nil {
+				return err
+			}
+		}
+
+		if len(redemptions) < exportPageSize {
+			return nil
+		}
+		afterID = redemptions[len(redemptions)-1].ID
+	}
+}
+
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(utils.BangkokLocation).Format(time.DateTime)
+}
+
+// extraAttributeColumns lists the json keys of every per-participant-type extra attributes struct,
+// keys shared by several types (e.g. school_name) become a single column.
+func extraAttributeColumns() []string {
+	seen := make(map[string]bool)
+	columns := make([]string, 0)
+
+	fields := reflect.TypeOf(models.ExtraAttributesFields{})
+	for i := 0; i < fields.NumField(); i++ {
+		attrs := fields.Field(i).Type.Elem()
+		for j := 0; j < attrs.NumField(); j++ {
+			key := strings.Split(attrs.Field(j).Tag.Get("json"), ",")[0]
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			columns = append(columns, "extra_"+key)
+		}
+	}
+
+	return columns
+}
+
+func flattenExtraAttributes(raw json.RawMessage, columns []string) []string {
+	values := make([]string, len(columns))
+	if len(raw) == 0 {
+		return values
+	}
+
+	var attrs map[string]any
+	if err := json.Unmarshal(raw, &attrs); err != nil {
+		return values
+	}
+
+	for i, column := range columns {
+		switch v := attrs[strings.TrimPrefix(column, "extra_")].(type) {
+		case nil:
+		case string:
+			values[i] = v
+		case float64:
+			values[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			encoded, _ := json.Marshal(v)
+			values[i] = string(encoded)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+)
+
+// healthCheckTimeout bounds each readiness check, Cloud Run probes time out after a few seconds
+const healthCheckTimeout = 2 * time.Second
+
+var (
+	ErrDatabaseUnavailable = errors.New("database is unavailable")
+	ErrMigrationsPending   = errors.New("database schema is behind the expected migration version")
+)
+
+type Readiness struct {
+	MigrationVersion         int64
+	ExpectedMigrationVersion int64
+}
+
+// HealthUsecase reports whether the instance can serve requests.
+type HealthUsecase interface {
+	// Ready checks that Postgres answers and that every embedded migration is applied. The readiness
+	// is returned with ErrMigrationsPending too, so the versions can be reported.
+	Ready(ctx context.Context) (Readiness, error)
+}
+
+type healthUsecaseImpl struct {
+	healthRepo      repositories.HealthRepo
+	expectedVersion int64
+}
+
+func NewHealthUsecase(healthRepo repositories.HealthRepo, expectedVersion int64) HealthUsecase {
+	return &healthUsecaseImpl{
+		healthRepo:      healthRepo,
+		expectedVersion: expectedVersion,
+	}
+}
+
+func (u *healthUsecaseImpl) Ready(ctx context.Context) (Readiness, error) {
+	ctx, span := tracer.Start(ctx, "HealthUsecase.Ready")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	readiness := Readiness{ExpectedMigrationVersion: u.expectedVersion}
+	if err := u.healthRepo.Ping(ctx); err != nil {
+		return readiness, errors.Join(ErrDatabaseUnavailable, err)
+	}
+
+	version, err := u.healthRepo.GetMigrationVersion(ctx)
+	if err != nil {
+		return readiness, errors.Join(ErrDatabaseUnavailable, err)
+	}
+	readiness.MigrationVersion = version
+
+	// A newer schema is fine, it is applied by the next revision during a rolling deploy
+	if version < u.expectedVersion {
+		return readiness, ErrMigrationsPending
+	}
+	return readiness, nil
+}
@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/config"
+)
+
+var (
+	ErrIdempotencyKeyReused     = errors.New("idempotency key reused for a different request")
+	ErrIdempotencyKeyInProgress = errors.New("request with this idempotency key is in progress")
+)
+
+// IdempotencyUsecase keeps the first response per user and Idempotency-Key for the configured TTL.
+type IdempotencyUsecase interface {
+	// Begin claims the key for a request and returns nil, or returns the completed record to replay.
+	// It fails when the key was used for a request with another fingerprint or is still being handled.
+	Begin(ctx context.Context, userEmail string, key string, fingerprint string) (*models.IdempotencyRecord, error)
+	Complete(ctx context.Context, userEmail string, key string, statusCode int, contentType string, body []byte) error
+	// Release forgets a claimed key whose request failed, so that a retry is handled again.
+	Release(ctx context.Context, userEmail string, key string) error
+	// Run purges expired keys every purge interval until ctx is done.
+	Run(ctx context.Context)
+}
+
+// idempotencyLeaseMargin covers storing the outcome after the request deadline.
+const idempotencyLeaseMargin = 30 * time.Second
+
+type idempotencyUsecaseImpl struct {
+	idempotencyRepo repositories.IdempotencyRepo
+	cfg             config.Idempotency
+	lease           time.Duration
+}
+
+// NewIdempotencyUsecase leases a key to its request for requestTimeout and a margin, a retry after the lease
+// takes over the key of a request that crashed.
+func NewIdempotencyUsecase(idempotencyRepo repositories.IdempotencyRepo, cfg config.Idempotency, requestTimeout time.Duration) IdempotencyUsecase {
+	return &idempotencyUsecaseImpl{
+		idempotencyRepo: idempotencyRepo,
+		cfg:             cfg,
+		lease:           requestTimeout + idempotencyLeaseMargin,
+	}
+}
+
+func (u *idempotencyUsecaseImpl) Begin(ctx context.Context, userEmail string, key string, fingerprint string) (*models.IdempotencyRecord, error) {
+	ctx, span := tracer.Start(ctx, "IdempotencyUsecase.Begin")
+	defer span.End()
+
+	record, claimed, err := u.idempotencyRepo.ClaimKey(ctx, &models.IdempotencyRecord{
+		UserEmail:   userEmail,
+		Key:         key,
+		Fingerprint: fingerprint,
+	}, u.cfg.TTL, u.lease)
+	if err != nil {
+		if err == repositories.ErrIdempotencyKeyNotFound {
+			// The first request failed while we were looking, the client retries
+			return nil, ErrIdempotencyKeyInProgress
+		}
+		return nil, err
+	}
+	if claimed {
+		return nil, nil
+	}
+
+	if record.Fingerprint != fingerprint {
+		return nil, ErrIdempotencyKeyReused
+	}
+	if record.CompletedAt == nil {
+		return nil, ErrIdempotencyKeyInProgress
+	}
+	return record, nil
+}
+
+func (u *idempotencyUsecaseImpl) Complete(ctx context.Context, userEmail string, key string, statusCode int, contentType string, body []byte) error {
+	ctx, span := tracer.Start(ctx, "IdempotencyUsecase.Complete")
+	defer span.End()
+
+	return u.idempotencyRepo.CompleteKey(ctx, userEmail, key, statusCode, contentType, body)
+}
+
+func (u *idempotencyUsecaseImpl) Release(ctx context.Context, userEmail string, key string) error {
+	ctx, span := tracer.Start(ctx, "IdempotencyUsecase.Release")
+	defer span.End()
+
+	return u.idempotencyRepo.ReleaseKey(ctx, userEmail, key)
+}
+
+func (u *idempotencyUsecaseImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(u.cfg.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := u.idempotencyRepo.DeleteExpiredKeys(ctx, u.cfg.TTL)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "idempotency: delete expired keys", "error", err)
+		}
+		if n > 0 {
+			slog.InfoContext(ctx, "idempotency: deleted expired keys", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
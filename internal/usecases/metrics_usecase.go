@@ -0,0 +1,62 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/metrics"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+)
+
+// MetricsEventTypes are the events counted by MetricsUsecase.
+var MetricsEventTypes = []models.EventType{
+	models.EventBookingCreated,
+	models.EventWorkshopAttended,
+	models.EventBoothCheckedIn,
+	models.EventStampsRedeemed,
+}
+
+// MetricsUsecase counts domain events for Prometheus. Counting dispatched events rather than requests
+// only counts committed changes, including waitlist promotions made by cancellations, and every event
+// is counted by a single instance.
+type MetricsUsecase interface {
+	// HandleEvent is an EventHandler. An event delivered again after another subscriber failed is
+	// counted again, which is acceptable for dashboards.
+	HandleEvent(ctx context.Context, event models.DomainEvent) error
+}
+
+type metricsUsecaseImpl struct{}
+
+func NewMetricsUsecase() MetricsUsecase {
+	return &metricsUsecaseImpl{}
+}
+
+func (u *metricsUsecaseImpl) HandleEvent(ctx context.Context, event models.DomainEvent) error {
+	switch event.Type {
+	case models.EventBookingCreated:
+		var payload models.BookingEventPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		source := "direct"
+		if payload.FromWaitlist {
+			source = "waitlist"
+		}
+		metrics.BookingsCreated.WithLabelValues(source).Inc()
+
+	case models.EventWorkshopAttended, models.EventBoothCheckedIn:
+		var payload models.CheckInEventPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		metrics.CheckIns.WithLabelValues(payload.Type).Inc()
+
+	case models.EventStampsRedeemed:
+		var payload models.StampsRedeemedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		metrics.Redemptions.WithLabelValues(string(payload.Category)).Inc()
+	}
+	return nil
+}
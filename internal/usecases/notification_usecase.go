@@ -0,0 +1,140 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/config"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/notifier"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/utils"
+)
+
+const (
+	notificationBatchSize = 50
+	// notificationLease must be longer than a batch of sends that all time out
+	notificationLease = 30 * time.Minute
+)
+
+// NotificationUsecase delivers the notifications queued in the outbox by booking changes.
+type NotificationUsecase interface {
+	// Run queues reminders and sends pending notifications every poll interval until ctx is done.
+	Run(ctx context.Context)
+}
+
+type notificationUsecaseImpl struct {
+	repo     repositories.NotificationRepo
+	notifier notifier.Notifier
+	cfg      config.Notifier
+}
+
+func NewNotificationUsecase(
+	repo repositories.NotificationRepo,
+	notifier notifier.Notifier,
+	cfg config.Notifier,
+) NotificationUsecase {
+	return &notificationUsecaseImpl{
+		repo:     repo,
+		notifier: notifier,
+		cfg:      cfg,
+	}
+}
+
+func (u *notificationUsecaseImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(u.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := u.repo.EnqueueDueReminders(ctx, u.cfg.ReminderLead); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "notification: queue reminders", "error", err)
+		}
+		// Keep draining while full batches come back
+		for {
+			n, err := u.sendBatch(ctx)
+			if err != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "notification: send batch", "error", err)
+			}
+			if err != nil || n < notificationBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendBatch sends outside of any transaction, the claimed rows are leased so that concurrent workers never
+// send twice, and each row is marked on its own as soon as its send returns.
+func (u *notificationUsecaseImpl) sendBatch(ctx context.Context) (int, error) {
+	pending, err := u.repo.ClaimPendingNotifications(ctx, u.cfg.MaxAttempts, notificationLease, notificationBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, p := range pending {
+		msg, ok := u.renderNotification(p)
+		if !ok {
+			// Nothing to say anymore, e.g. a reminder for a cancelled booking
+			if err := u.repo.MarkNotificationSent(ctx, p.ID); err != nil {
+				return len(pending), err
+			}
+			continue
+		}
+
+		if sendErr := u.notifier.Send(ctx, msg); sendErr != nil {
+			retryAt := time.Now().Add(notificationBackoff(p.Attempts))
+			if err := u.repo.MarkNotificationFailed(ctx, p.ID, sendErr, retryAt); err != nil {
+				return len(pending), err
+			}
+			continue
+		}
+		if err := u.repo.MarkNotificationSent(ctx, p.ID); err != nil {
+			return len(pending), err
+		}
+	}
+
+	return len(pending), nil
+}
+
+// notificationBackoff grows quadratically: 1, 4, 9, ... minutes.
+func notificationBackoff(attempts int) time.Duration {
+	n := time.Duration(attempts + 1)
+	return n * n * time.Minute
+}
+
+// renderNotification returns false when the notification should be dropped.
+func (u *notificationUsecaseImpl) renderNotification(p models.PendingNotification) (notifier.Message, bool) {
+	if p.UserDeletedAt != nil {
+		return notifier.Message{}, false
+	}
+
+	when := fmt.Sprintf("%s %s-%s", utils.FormatDate(p.EventDate), p.StartTime.Format("15:04"), p.EndTime.Format("15:04"))
+	details := fmt.Sprintf("Workshop: %s\nTime: %s\nLocation: %s\n", p.WorkshopName, when, p.Location)
+
+	msg := notifier.Message{To: p.Email}
+	switch p.Kind {
+	case models.NotificationBookingConfirmed:
+		msg.Subject = "Booking confirmed: " + p.WorkshopName
+		msg.Body = fmt.Sprintf("Hi %s,\n\nYour booking is confirmed.\n\n%s\nPlease show your e-ticket to the staff when you arrive.\n", p.FirstName, details)
+	case models.NotificationBookingCancelled:
+		msg.Subject = "Booking cancelled: " + p.WorkshopName
+		msg.Body = fmt.Sprintf("Hi %s,\n\nYour booking has been cancelled.\n\n%s", p.FirstName, details)
+	case models.NotificationWorkshopReminder:
+		if p.BookingStatus != models.StatusConfirmed {
+			return notifier.Message{}, false
+		}
+		msg.Subject = "Starting soon: " + p.WorkshopName
+		msg.Body = fmt.Sprintf("Hi %s,\n\nYour workshop starts in about %d minutes.\n\n%s", p.FirstName, int(u.cfg.ReminderLead.Minutes()), details)
+	default:
+		return notifier.Message{}, false
+	}
+
+	return msg, true
+}
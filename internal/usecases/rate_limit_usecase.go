@@ -0,0 +1,121 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/config"
+)
+
+// RateLimitRoute names the endpoint whose limits apply, see config.RateLimit.
+type RateLimitRoute string
+
+const (
+	RateLimitBook       RateLimitRoute = "book"
+	RateLimitCheckIn    RateLimitRoute = "check_in"
+	RateLimitRedemption RateLimitRoute = "redemption"
+
+	// rateLimitInvalidCheckIn counts invalid check-in codes per user
+	rateLimitInvalidCheckIn RateLimitRoute = "invalid_check_in"
+)
+
+// RateLimitUsecase throttles hot endpoints per Firebase UID and per client IP.
+type RateLimitUsecase interface {
+	// Allow takes a token from the user and IP buckets of route, it returns zero when the request may
+	// proceed and otherwise how long the client should wait.
+	Allow(ctx context.Context, route RateLimitRoute, uid string, ip string) (time.Duration, error)
+	// RecordInvalidCheckIn counts an invalid check-in code and locks the user out of check-in
+	// once they entered too many.
+	RecordInvalidCheckIn(ctx context.Context, uid string) error
+	// Run purges idle buckets every purge interval until ctx is done.
+	Run(ctx context.Context)
+}
+
+type rateLimitUsecaseImpl struct {
+	rateLimitRepo repositories.RateLimitRepo
+	rules         map[RateLimitRoute]config.RateLimitRule
+	cfg           config.RateLimit
+}
+
+func NewRateLimitUsecase(rateLimitRepo repositories.RateLimitRepo, cfg config.RateLimit) RateLimitUsecase {
+	return &rateLimitUsecaseImpl{
+		rateLimitRepo: rateLimitRepo,
+		rules: map[RateLimitRoute]config.RateLimitRule{
+			RateLimitBook:       cfg.Book,
+			RateLimitCheckIn:    cfg.CheckIn,
+			RateLimitRedemption: cfg.Redemption,
+		},
+		cfg: cfg,
+	}
+}
+
+func rateLimitKey(route RateLimitRoute, kind string, id string) string {
+	return fmt.Sprintf("%s:%s:%s", route, kind, id)
+}
+
+func (u *rateLimitUsecaseImpl) Allow(ctx context.Context, route RateLimitRoute, uid string, ip string) (time.Duration, error) {
+	ctx, span := tracer.Start(ctx, "RateLimitUsecase.Allow")
+	defer span.End()
+
+	rule := u.rules[route]
+	if rule.Period <= 0 {
+		return 0, nil
+	}
+
+	if rule.PerUser > 0 && uid != "" {
+		wait, err := u.rateLimitRepo.TakeToken(ctx, rateLimitKey(route, "user", uid), rule.PerUser, rule.Period)
+		if err != nil || wait > 0 {
+			return wait, err
+		}
+	}
+	if rule.PerIP > 0 && ip != "" {
+		return u.rateLimitRepo.TakeToken(ctx, rateLimitKey(route, "ip", ip), rule.PerIP, rule.Period)
+	}
+	return 0, nil
+}
+
+func (u *rateLimitUsecaseImpl) RecordInvalidCheckIn(ctx context.Context, uid string) error {
+	ctx, span := tracer.Start(ctx, "RateLimitUsecase.RecordInvalidCheckIn")
+	defer span.End()
+
+	if u.cfg.MaxInvalidCheckIns <= 0 || u.cfg.InvalidCheckInWindow <= 0 || uid == "" {
+		return nil
+	}
+
+	wait, err := u.rateLimitRepo.TakeToken(ctx, rateLimitKey(rateLimitInvalidCheckIn, "user", uid), u.cfg.MaxInvalidCheckIns, u.cfg.InvalidCheckInWindow)
+	if err != nil || wait == 0 {
+		return err
+	}
+	// The lock is held by the check-in bucket of the user, which Allow checks before every attempt
+	return u.rateLimitRepo.LockKey(ctx, rateLimitKey(RateLimitCheckIn, "user", uid), u.cfg.CheckInLockout)
+}
+
+func (u *rateLimitUsecaseImpl) Run(ctx context.Context) {
+	// A bucket untouched for its longest period is full again, deleting it changes nothing
+	idle := u.cfg.InvalidCheckInWindow
+	for _, rule := range u.rules {
+		idle = max(idle, rule.Period)
+	}
+
+	ticker := time.NewTicker(u.cfg.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := u.rateLimitRepo.DeleteIdleBuckets(ctx, idle)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "rate limit: delete idle buckets", "error", err)
+		}
+		if n > 0 {
+			slog.InfoContext(ctx, "rate limit: deleted idle buckets", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/config"
+)
+
+func TestRateLimitAllow(t *testing.T) {
+	ctx := context.Background()
+	u := NewRateLimitUsecase(repositories.NewMemoryRateLimitRepo(), config.RateLimit{
+		Book: config.RateLimitRule{PerUser: 2, PerIP: 3, Period: time.Hour},
+	})
+
+	tests := []struct {
+		name    string
+		route   RateLimitRoute
+		uid     string
+		ip      string
+		limited bool
+	}{
+		{name: "first request", route: RateLimitBook, uid: "a", ip: "10.0.0.1"},
+		{name: "second request", route: RateLimitBook, uid: "a", ip: "10.0.0.1"},
+		{name: "user bucket empty", route: RateLimitBook, uid: "a", ip: "10.0.0.1", limited: true},
+		{name: "other user on the same IP", route: RateLimitBook, uid: "b", ip: "10.0.0.1"},
+		{name: "IP bucket empty", route: RateLimitBook, uid: "c", ip: "10.0.0.1", limited: true},
+		{name: "route without a rule", route: RateLimitCheckIn, uid: "a", ip: "10.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, err := u.Allow(ctx, tt.route, tt.uid, tt.ip)
+			if err != nil {
+				t.Fatalf("Allow() error = %v", err)
+			}
+			if (wait > 0) != tt.limited {
+				t.Errorf("Allow() wait = %v, want limited %v", wait, tt.limited)
+			}
+		})
+	}
+}
+
+func TestRecordInvalidCheckInLockout(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.RateLimit{
+		CheckIn:              config.RateLimitRule{PerUser: 100, Period: time.Minute},
+		MaxInvalidCheckIns:   3,
+		InvalidCheckInWindow: time.Hour,
+		CheckInLockout:       15 * time.Minute,
+	}
+	u := NewRateLimitUsecase(repositories.NewMemoryRateLimitRepo(), cfg)
+
+	for i := range cfg.MaxInvalidCheckIns {
+		if err := u.RecordInvalidCheckIn(ctx, "a"); err != nil {
+			t.Fatalf("RecordInvalidCheckIn() error = %v", err)
+		}
+		if wait, _ := u.Allow(ctx, RateLimitCheckIn, "a", ""); wait > 0 {
+			t.Fatalf("locked out after %d invalid codes, want a lockout after %d", i+1, cfg.MaxInvalidCheckIns+1)
+		}
+	}
+
+	if err := u.RecordInvalidCheckIn(ctx, "a"); err != nil {
+		t.Fatalf("RecordInvalidCheckIn() error = %v", err)
+	}
+	wait, err := u.Allow(ctx, RateLimitCheckIn, "a", "")
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+	if wait <= cfg.CheckInLockout-time.Minute || wait > cfg.CheckInLockout {
+		t.Errorf("Allow() wait = %v, want about %v", wait, cfg.CheckInLockout)
+	}
+
+	// The lockout is per user
+	if wait, _ := u.Allow(ctx, RateLimitCheckIn, "b", ""); wait > 0 {
+		t.Errorf("other user waits %v, want no wait", wait)
+	}
+}
@@ -0,0 +1,196 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/config"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/pglisten"
+)
+
+// subscriberBuffer is how many updates a slow client may lag behind before updates are dropped for it.
+const subscriberBuffer = 64
+
+// seatRefreshInterval is how often the seats are re-read while clients are connected, staged seat releases
+// and booking windows open and close with time, so no write notifies them.
+const seatRefreshInterval = 30 * time.Second
+
+// SeatStreamUsecase fans out seat updates from Postgres LISTEN/NOTIFY to every connected client,
+// so updates made by any server instance reach clients of all instances.
+type SeatStreamUsecase interface {
+	// Run listens for notifications until ctx is done.
+	Run(ctx context.Context)
+	// Snapshot returns the current seats of every workshop.
+	Snapshot(ctx context.Context) ([]models.WorkshopSeatUpdate, error)
+	// Subscribe returns a channel of updates and a function to unsubscribe.
+	Subscribe() (<-chan models.WorkshopSeatUpdate, func())
+	// Close closes the channel of every subscriber, later subscribers get a closed channel.
+	Close()
+}
+
+type seatStreamUsecaseImpl struct {
+	workshopRepo repositories.WorkshopRepo
+	dsn          string
+
+	mu          sync.Mutex
+	subscribers map[chan models.WorkshopSeatUpdate]struct{}
+	last        map[int64]models.WorkshopSeatUpdate // latest update published per workshop
+	closed      bool
+}
+
+func NewSeatStreamUsecase(workshopRepo repositories.WorkshopRepo, databaseCfg config.Database) SeatStreamUsecase {
+	return &seatStreamUsecaseImpl{
+		workshopRepo: workshopRepo,
+		dsn:          databaseCfg.DSN,
+		subscribers:  make(map[chan models.WorkshopSeatUpdate]struct{}),
+		last:         make(map[int64]models.WorkshopSeatUpdate),
+	}
+}
+
+func (u *seatStreamUsecaseImpl) Run(ctx context.Context) {
+	var refresher sync.WaitGroup
+	refresher.Add(1)
+	go func() {
+		defer refresher.Done()
+		ticker := time.NewTicker(seatRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				u.refresh(ctx)
+			}
+		}
+	}()
+	defer refresher.Wait()
+
+	pglisten.Listen(ctx, u.dsn, repositories.WorkshopSeatsChannel, u.handleNotification, func() {
+		// Notifications sent while disconnected are lost, so resend everything
+		u.resync(ctx)
+	})
+}
+
+func (u *seatStreamUsecaseImpl) Snapshot(ctx context.Context) ([]models.WorkshopSeatUpdate, error) {
+	ctx, span := tracer.Start(ctx, "SeatStreamUsecase.Snapshot")
+	defer span.End()
+
+	workshops, err := u.workshopRepo.ListWorkshop(ctx, models.WorkshopFilter{SortBy: "start_time", Order: "asc"})
+	if err != nil {
+		return nil, err
+	}
+
+	seats := make([]models.WorkshopSeatUpdate, 0, len(workshops))
+	for _, w := range workshops {
+		seats = append(seats, models.WorkshopSeatUpdate{
+			WorkshopID:        w.ID,
+			RegisteredCount:   w.RegisteredCount,
+			TotalSeats:        w.TotalSeats,
+			AvailableSeatsNow: w.AvailableSeatsNow,
+		})
+	}
+	return seats, nil
+}
+
+func (u *seatStreamUsecaseImpl) Subscribe() (<-chan models.WorkshopSeatUpdate, func()) {
+	ch := make(chan models.WorkshopSeatUpdate, subscriberBuffer)
+
+	u.mu.Lock()
+	if u.closed {
+		close(ch)
+	} else {
+		u.subscribers[ch] = struct{}{}
+	}
+	u.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			u.mu.Lock()
+			delete(u.subscribers, ch)
+			u.mu.Unlock()
+		})
+	}
+}
+
+func (u *seatStreamUsecaseImpl) Close() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.closed = true
+	for ch := range u.subscribers {
+		close(ch)
+		delete(u.subscribers, ch)
+	}
+}
+
+func (u *seatStreamUsecaseImpl) handleNotification(payload string) {
+	var update models.WorkshopSeatUpdate
+	if err := json.Unmarshal([]byte(payload), &update); err != nil {
+		slog.Error("seat stream: invalid payload", "payload", payload, "error", err)
+		return
+	}
+	u.publish(update)
+}
+
+func (u *seatStreamUsecaseImpl) resync(ctx context.Context) {
+	u.mu.Lock()
+	hasSubscribers := len(u.subscribers) > 0
+	u.mu.Unlock()
+	if !hasSubscribers {
+		return
+	}
+
+	seats, err := u.Snapshot(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "seat stream: resync failed", "error", err)
+		return
+	}
+	for _, s := range seats {
+		u.publish(s)
+	}
+}
+
+// refresh publishes the workshops whose seats differ from their last update.
+func (u *seatStreamUsecaseImpl) refresh(ctx context.Context) {
+	u.mu.Lock()
+	hasSubscribers := len(u.subscribers) > 0
+	u.mu.Unlock()
+	if !hasSubscribers {
+		return
+	}
+
+	seats, err := u.Snapshot(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "seat stream: refresh failed", "error", err)
+		return
+	}
+	for _, s := range seats {
+		u.mu.Lock()
+		last, ok := u.last[s.WorkshopID]
+		u.mu.Unlock()
+		if !ok || last != s {
+			u.publish(s)
+		}
+	}
+}
+
+func (u *seatStreamUsecaseImpl) publish(update models.WorkshopSeatUpdate) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.last[update.WorkshopID] = update
+
+	for ch := range u.subscribers {
+		select {
+		case ch <- update:
+		default:
+			// Never block the listener on a slow client, the next update carries the absolute count anyway
+		}
+	}
+}
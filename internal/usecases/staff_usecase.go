@@ -0,0 +1,110 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+)
+
+var ErrInvalidStaffRole = errors.New("invalid staff role")
+
+type StaffUsecase interface {
+	GetRoles(ctx context.Context, email string) ([]models.StaffRole, error)
+	ListStaff(ctx context.Context) ([]models.StaffRoleAssignment, error)
+	AssignRole(ctx context.Context, email string, role models.StaffRole) (*models.StaffRoleAssignment, error)
+	RevokeRole(ctx context.Context, email string, role models.StaffRole) error
+	ListAssignments(ctx context.Context) ([]models.StaffAssignment, error)
+	// Assign scopes a staff member to a workshop or a booth, exactly one of workshopID and boothID must be set.
+	Assign(ctx context.Context, email string, workshopID *int64, boothID *int64) (*models.StaffAssignment, error)
+	Unassign(ctx context.Context, id int64) error
+}
+
+type staffUsecaseImpl struct {
+	staffRepo repositories.StaffRepo
+}
+
+func NewStaffUsecase(staffRepo repositories.StaffRepo) StaffUsecase {
+	return &staffUsecaseImpl{
+		staffRepo: staffRepo,
+	}
+}
+
+func (u *staffUsecaseImpl) GetRoles(ctx context.Context, email string) ([]models.StaffRole, error) {
+	ctx, span := tracer.Start(ctx, "StaffUsecase.GetRoles")
+	defer span.End()
+
+	return u.staffRepo.GetRolesByEmail(ctx, normalizeEmail(email))
+}
+
+func (u *staffUsecaseImpl) ListStaff(ctx context.Context) ([]models.StaffRoleAssignment, error) {
+	ctx, span := tracer.Start(ctx, "StaffUsecase.ListStaff")
+	defer span.End()
+
+	return u.staffRepo.ListStaffRoles(ctx)
+}
+
+func (u *staffUsecaseImpl) AssignRole(ctx context.Context, email string, role models.StaffRole) (*models.StaffRoleAssignment, error) {
+	ctx, span := tracer.Start(ctx, "StaffUsecase.AssignRole")
+	defer span.End()
+
+	if !slices.Contains(models.StaffRoles, role) {
+		return nil, ErrInvalidStaffRole
+	}
+
+	assignment := &models.StaffRoleAssignment{
+		Email: normalizeEmail(email),
+		Role:  role,
+	}
+	if err := u.staffRepo.AssignRole(ctx, assignment); err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+func (u *staffUsecaseImpl) RevokeRole(ctx context.Context, email string, role models.StaffRole) error {
+	ctx, span := tracer.Start(ctx, "StaffUsecase.RevokeRole")
+	defer span.End()
+
+	return u.staffRepo.RevokeRole(ctx, normalizeEmail(email), role)
+}
+
+func (u *staffUsecaseImpl) ListAssignments(ctx context.Context) ([]models.StaffAssignment, error) {
+	ctx, span := tracer.Start(ctx, "StaffUsecase.ListAssignments")
+	defer span.End()
+
+	return u.staffRepo.ListAssignments(ctx)
+}
+
+func (u *staffUsecaseImpl) Assign(ctx context.Context, email string, workshopID *int64, boothID *int64) (*models.StaffAssignment, error) {
+	ctx, span := tracer.Start(ctx, "StaffUsecase.Assign")
+	defer span.End()
+
+	if (workshopID == nil) == (boothID == nil) {
+		return nil, ErrCheckInTargetRequired
+	}
+
+	assignment := &models.StaffAssignment{
+		Email:      normalizeEmail(email),
+		WorkshopID: workshopID,
+		BoothID:    boothID,
+	}
+	if err := u.staffRepo.CreateAssignment(ctx, assignment); err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+func (u *staffUsecaseImpl) Unassign(ctx context.Context, id int64) error {
+	ctx, span := tracer.Start(ctx, "StaffUsecase.Unassign")
+	defer span.End()
+
+	return u.staffRepo.DeleteAssignment(ctx, id)
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
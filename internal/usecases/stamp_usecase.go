@@ -7,6 +7,7 @@ import (
 
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
 )
 
 const (
@@ -26,24 +27,32 @@ type StampUsecase interface {
 }
 
 type stampUsecaseImpl struct {
-	stampRepo   repositories.StampRepo
-	bookingRepo repositories.BookingRepo
-	boothRepo   repositories.BoothRepo
+	stampRepo     repositories.StampRepo
+	bookingRepo   repositories.BookingRepo
+	boothRepo     repositories.BoothRepo
+	eventRepo     repositories.EventRepo
+	transactioner baserepo.Transactioner
 }
 
 func NewStampUsecase(
 	stampRepo repositories.StampRepo,
 	bookingRepo repositories.BookingRepo,
 	boothRepo repositories.BoothRepo,
+	eventRepo repositories.EventRepo,
+	transactioner baserepo.Transactioner,
 ) StampUsecase {
 	return &stampUsecaseImpl{
-		stampRepo:   stampRepo,
-		bookingRepo: bookingRepo,
-		boothRepo:   boothRepo,
+		stampRepo:     stampRepo,
+		bookingRepo:   bookingRepo,
+		boothRepo:     boothRepo,
+		eventRepo:     eventRepo,
+		transactioner: transactioner,
 	}
 }
 
 func (u *stampUsecaseImpl) GetUserStamps(ctx context.Context, userID int64) (*models.UserStamps, error) {
+	ctx, span := tracer.Start(ctx, "StampUsecase.GetUserStamps")
+	defer span.End()
 
 	// Get booth stamps (booth check-ins)
 	stamps, err := u.boothRepo.GetBoothCheckInsForUser(ctx, userID)
@@ -88,6 +97,9 @@ func (u *stampUsecaseImpl) GetUserStamps(ctx context.Context, userID int64) (*mo
 }
 
 func (u *stampUsecaseImpl) GetMyStampPosters(ctx context.Context, userID int64) (*models.StampRedemptionStatus, error) {
+	ctx, span := tracer.Start(ctx, "StampUsecase.GetMyStampPosters")
+	defer span.End()
+
 	posters, err := u.stampRepo.GetUserStampPosters(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -125,6 +137,8 @@ func (u *stampUsecaseImpl) GetMyStampPosters(ctx context.Context, userID int64)
 }
 
 func (u *stampUsecaseImpl) RedeemStamps(ctx context.Context, userID int64, category models.StampType) error {
+	ctx, span := tracer.Start(ctx, "StampUsecase.RedeemStamps")
+	defer span.End()
 
 	status, err := u.GetMyStampPosters(ctx, userID)
 	if err != nil {
@@ -156,7 +170,16 @@ func (u *stampUsecaseImpl) RedeemStamps(ctx context.Context, userID int64, categ
 		return ErrNotEnoughStamps
 	}
 
-	if err := u.stampRepo.RedeemStamps(ctx, userID, category); err != nil {
+	err = u.transactioner.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.stampRepo.RedeemStamps(ctx, userID, category); err != nil {
+			return err
+		}
+		return u.eventRepo.AppendEvent(ctx, models.EventStampsRedeemed, models.StampsRedeemedPayload{
+			UserID:   userID,
+			Category: category,
+		})
+	})
+	if err != nil {
 		if errors.Is(err, repositories.ErrStampPosterAlreadyRedeemed) {
 			return ErrStampPosterAlreadyRedeemed
 		}
@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/config"
+)
+
+type StatsUsecase interface {
+	GetStats(ctx context.Context) (*models.EventStats, error)
+}
+
+type statsUsecaseImpl struct {
+	statsRepo repositories.StatsRepo
+	cacheTTL  time.Duration
+
+	// mu is held while computing so that concurrent dashboards share one set of queries
+	mu        sync.Mutex
+	cached    *models.EventStats
+	expiresAt time.Time
+}
+
+func NewStatsUsecase(statsRepo repositories.StatsRepo, statsCfg config.Stats) StatsUsecase {
+	return &statsUsecaseImpl{
+		statsRepo: statsRepo,
+		cacheTTL:  statsCfg.CacheTTL,
+	}
+}
+
+func (u *statsUsecaseImpl) GetStats(ctx context.Context) (*models.EventStats, error) {
+	ctx, span := tracer.Start(ctx, "StatsUsecase.GetStats")
+	defer span.End()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.cached != nil && time.Now().Before(u.expiresAt) {
+		return u.cached, nil
+	}
+
+	stats, err := u.computeStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u.cached = stats
+	u.expiresAt = stats.GeneratedAt.Add(u.cacheTTL)
+	return stats, nil
+}
+
+func (u *statsUsecaseImpl) computeStats(ctx context.Context) (*models.EventStats, error) {
+	stats := &models.EventStats{GeneratedAt: time.Now()}
+	var err error
+
+	if stats.TotalUsers, err = u.statsRepo.CountUsers(ctx); err != nil {
+		return nil, err
+	}
+	if stats.UsersByParticipantType, err = u.statsRepo.CountUsersBy(ctx, "participant_type"); err != nil {
+		return nil, err
+	}
+	if stats.UsersByOriginLocation, err = u.statsRepo.CountUsersBy(ctx, "origin_location"); err != nil {
+		return nil, err
+	}
+	if stats.UsersByIsFromBangkok, err = u.statsRepo.CountUsersBy(ctx, "is_from_bangkok"); err != nil {
+		return nil, err
+	}
+	if stats.UsersByTransportMode, err = u.statsRepo.CountUsersBy(ctx, "transport_mode"); err != nil {
+		return nil, err
+	}
+	if stats.BookingsByStatus, err = u.statsRepo.CountBookingsByStatus(ctx); err != nil {
+		return nil, err
+	}
+	if stats.WorkshopFill, err = u.statsRepo.GetWorkshopFill(ctx); err != nil {
+		return nil, err
+	}
+	if stats.BoothCheckInsPerHour, err = u.statsRepo.CountBoothCheckInsPerHour(ctx); err != nil {
+		return nil, err
+	}
+	if stats.StampRedemptionsByType, err = u.statsRepo.CountStampRedemptions(ctx); err != nil {
+		return nil, err
+	}
+
+	stats.Attendance = computeAttendance(stats.BookingsByStatus)
+	return stats, nil
+}
+
+func computeAttendance(bookingsByStatus []models.CountByKey) models.AttendanceStats {
+	var attendance models.AttendanceStats
+	for _, c := range bookingsByStatus {
+		switch models.Status(c.Key) {
+		case models.StatusAttended:
+			attendance.Attended = c.Count
+		case models.StatusAbsent:
+			attendance.Absent = c.Count
+		}
+	}
+
+	if total := attendance.Attended + attendance.Absent; total > 0 {
+		attendance.AttendanceRate = float64(attendance.Attended) / float64(total)
+		attendance.AbsentRate = float64(attendance.Absent) / float64(total)
+	}
+	return attendance
+}
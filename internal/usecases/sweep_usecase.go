@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/config"
+)
+
+const (
+	sweepBatchSize = 500
+	// sweepReasonNoShow is recorded in booking_status_changes for bookings marked Absent by the sweeper
+	sweepReasonNoShow = "no_show"
+)
+
+// SweepUsecase materialises the Absent status of bookings nobody checked in to before the workshop ended.
+type SweepUsecase interface {
+	// SweepNoShows marks every Confirmed booking of an ended workshop as Absent and returns how many were marked.
+	SweepNoShows(ctx context.Context) (int64, error)
+	// Run sweeps every interval until ctx is done.
+	Run(ctx context.Context)
+}
+
+type sweepUsecaseImpl struct {
+	bookingRepo repositories.BookingRepo
+	cfg         config.Sweeper
+}
+
+func NewSweepUsecase(bookingRepo repositories.BookingRepo, cfg config.Sweeper) SweepUsecase {
+	return &sweepUsecaseImpl{
+		bookingRepo: bookingRepo,
+		cfg:         cfg,
+	}
+}
+
+func (u *sweepUsecaseImpl) SweepNoShows(ctx context.Context) (int64, error) {
+	ctx, span := tracer.Start(ctx, "SweepUsecase.SweepNoShows")
+	defer span.End()
+
+	var total int64
+	for {
+		// Each batch commits on its own so check-ins are not blocked behind one large update
+		n, err := u.bookingRepo.MarkNoShowsAbsent(ctx, sweepReasonNoShow, sweepBatchSize)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < sweepBatchSize {
+			return total, nil
+		}
+	}
+}
+
+func (u *sweepUsecaseImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(u.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := u.SweepNoShows(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "sweeper: sweep no-shows", "error", err)
+		}
+		if n > 0 {
+			slog.InfoContext(ctx, "sweeper: marked bookings as Absent", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
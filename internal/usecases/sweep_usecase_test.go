@@ -0,0 +1,70 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/config"
+)
+
+// fakeNoShowRepo marks the next batch of due bookings on every call and fails once failAt calls were made.
+type fakeNoShowRepo struct {
+	repositories.BookingRepo
+	due     int64
+	failAt  int
+	calls   int
+	reasons []string
+}
+
+func (r *fakeNoShowRepo) MarkNoShowsAbsent(ctx context.Context, reason string, limit int) (int64, error) {
+	r.calls++
+	r.reasons = append(r.reasons, reason)
+	if r.failAt > 0 && r.calls == r.failAt {
+		return 0, errors.New("connection reset")
+	}
+	n := min(r.due, int64(limit))
+	r.due -= n
+	return n, nil
+}
+
+func TestSweepNoShows(t *testing.T) {
+	tests := []struct {
+		name      string
+		due       int64
+		failAt    int
+		want      int64
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "nothing due", due: 0, want: 0, wantCalls: 1},
+		{name: "partial batch stops", due: 42, want: 42, wantCalls: 1},
+		{name: "full batches continue until a partial one", due: 2*sweepBatchSize + 1, want: 2*sweepBatchSize + 1, wantCalls: 3},
+		{name: "exactly one batch needs a second call", due: sweepBatchSize, want: sweepBatchSize, wantCalls: 2},
+		{name: "error keeps the count of earlier batches", due: 3 * sweepBatchSize, failAt: 2, want: sweepBatchSize, wantCalls: 2, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeNoShowRepo{due: tt.due, failAt: tt.failAt}
+			u := NewSweepUsecase(repo, config.Sweeper{})
+
+			got, err := u.SweepNoShows(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SweepNoShows() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SweepNoShows() = %d, want %d", got, tt.want)
+			}
+			if repo.calls != tt.wantCalls {
+				t.Errorf("MarkNoShowsAbsent called %d times, want %d", repo.calls, tt.wantCalls)
+			}
+			for _, reason := range repo.reasons {
+				if reason != sweepReasonNoShow {
+					t.Errorf("MarkNoShowsAbsent reason = %q, want %q", reason, sweepReasonNoShow)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package usecases
+
+import "go.opentelemetry.io/otel"
+
+// tracer starts the span of every usecase method, named <Interface>.<Method>.
+var tracer = otel.Tracer("github.com/esc-chula/intania-openhouse-2026-api/internal/usecases")
@@ -2,33 +2,54 @@ package usecases
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
 	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/myValidator"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/utils"
 )
 
+var ErrInvalidAttendanceDate = errors.New("invalid attendance date")
+
 // TODO:
 type UserUsecase interface {
 	CreateUser(ctx context.Context, user *models.User) error
 	GetUser(ctx context.Context, email string, fields []string) (*models.User, error)
+	UpdateUser(ctx context.Context, email string, update *models.UserOptional) (*models.User, error)
+	DeleteUser(ctx context.Context, email string) error
 }
 
 type userUsecaseImpl struct {
-	repo          repositories.UserRepo
-	stampRepo     repositories.StampRepo
-	transactioner baserepo.Transactioner
+	repo           repositories.UserRepo
+	stampRepo      repositories.StampRepo
+	eventRepo      repositories.EventRepo
+	bookingUsecase BookingUsecase
+	transactioner  baserepo.Transactioner
 }
 
-func NewUserUsecase(repo repositories.UserRepo, stampRepo repositories.StampRepo, transactioner baserepo.Transactioner) UserUsecase {
+func NewUserUsecase(
+	repo repositories.UserRepo,
+	stampRepo repositories.StampRepo,
+	eventRepo repositories.EventRepo,
+	bookingUsecase BookingUsecase,
+	transactioner baserepo.Transactioner,
+) UserUsecase {
 	return &userUsecaseImpl{
-		repo:          repo,
-		stampRepo:     stampRepo,
-		transactioner: transactioner,
+		repo:           repo,
+		stampRepo:      stampRepo,
+		eventRepo:      eventRepo,
+		bookingUsecase: bookingUsecase,
+		transactioner:  transactioner,
 	}
 }
 
 func (u *userUsecaseImpl) CreateUser(ctx context.Context, user *models.User) error {
+	ctx, span := tracer.Start(ctx, "UserUsecase.CreateUser")
+	defer span.End()
+
 	return u.transactioner.Transaction(ctx, func(ctx context.Context) error {
 		if err := u.repo.CreateUser(ctx, user); err != nil {
 			return err
@@ -40,10 +61,202 @@ func (u *userUsecaseImpl) CreateUser(ctx context.Context, user *models.User) err
 			{UserID: user.ID, Type: models.StampTypeExhibition},
 		}
 
-		return u.stampRepo.CreateStampPosters(ctx, stampPosters)
+		if err := u.stampRepo.CreateStampPosters(ctx, stampPosters); err != nil {
+			return err
+		}
+
+		return u.eventRepo.AppendEvent(ctx, models.EventUserRegistered, models.UserRegisteredPayload{
+			UserID:          user.ID,
+			ParticipantType: user.ParticipantType,
+		})
 	})
 }
 
 func (u *userUsecaseImpl) GetUser(ctx context.Context, email string, fields []string) (*models.User, error) {
+	ctx, span := tracer.Start(ctx, "UserUsecase.GetUser")
+	defer span.End()
+
 	return u.repo.GetUserByEmail(ctx, email, fields)
 }
+
+func (u *userUsecaseImpl) UpdateUser(ctx context.Context, email string, update *models.UserOptional) (*models.User, error) {
+	ctx, span := tracer.Start(ctx, "UserUsecase.UpdateUser")
+	defer span.End()
+
+	var user *models.User
+	err := u.transactioner.Transaction(ctx, func(ctx context.Context) error {
+		current, err := u.repo.GetUserForUpdate(ctx, email)
+		if err != nil {
+			return err
+		}
+		// DATE values are scanned as RFC3339, bring them back to the format accepted on input
+		for i, date := range current.AttendanceDates {
+			current.AttendanceDates[i] = utils.FormatDate(date)
+		}
+
+		merged, changed := mergeUser(current, update)
+		if !changed {
+			return ErrNothingToUpdate
+		}
+
+		// Extra attributes depend on the participant type, re-validate them whenever either changes
+		if update.ParticipantType != nil || update.ExtraAttributes != nil {
+			fields, err := storedExtraAttributes(current.ParticipantType, current.ExtraAttributes)
+			if err != nil {
+				return err
+			}
+			mergeExtraAttributes(fields, update.ExtraAttributes)
+			extraAttributes, err := myValidator.ValidateExtraAttributes(merged.ParticipantType, fields)
+			if err != nil {
+				return err
+			}
+			if extraAttributes == nil {
+				extraAttributes = json.RawMessage("{}")
+			}
+			merged.ExtraAttributes = extraAttributes
+		}
+		if err := myValidator.ValidateAttendanceDate(merged); err != nil {
+			return ErrInvalidAttendanceDate
+		}
+		if err := myValidator.ValidateUserEnums(merged); err != nil {
+			return err
+		}
+
+		if merged.ParticipantType != current.ParticipantType {
+			if err := u.bookingUsecase.CheckParticipantTypeChange(ctx, merged.ID, merged.ParticipantType); err != nil {
+				return err
+			}
+		}
+
+		if err := u.repo.UpdateUser(ctx, merged); err != nil {
+			return err
+		}
+		user = merged
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// DeleteUser cancels the bookings of a user, freeing their seats, then anonymises the account.
+func (u *userUsecaseImpl) DeleteUser(ctx context.Context, email string) error {
+	ctx, span := tracer.Start(ctx, "UserUsecase.DeleteUser")
+	defer span.End()
+
+	return u.transactioner.Transaction(ctx, func(ctx context.Context) error {
+		user, err := u.repo.GetUserForUpdate(ctx, email)
+		if err != nil {
+			return err
+		}
+
+		if err := u.bookingUsecase.CancelAllBookings(ctx, user.ID); err != nil {
+			return err
+		}
+
+		return u.repo.AnonymizeUser(ctx, user.ID)
+	})
+}
+
+// mergeUser applies the non-nil fields of update on a copy of current.
+func mergeUser(current *models.User, update *models.UserOptional) (*models.User, bool) {
+	merged := *current
+	changed := false
+
+	if update.FirstName != nil {
+		merged.FirstName, changed = *update.FirstName, true
+	}
+	if update.LastName != nil {
+		merged.LastName, changed = *update.LastName, true
+	}
+	if update.Gender != nil {
+		merged.Gender, changed = *update.Gender, true
+	}
+	if update.PhoneNumber != nil {
+		merged.PhoneNumber, changed = *update.PhoneNumber, true
+	}
+	if update.ParticipantType != nil {
+		merged.ParticipantType, changed = *update.ParticipantType, true
+	}
+	if update.TransportMode != nil {
+		merged.TransportMode, changed = *update.TransportMode, true
+	}
+	if update.IsFromBangkok != nil {
+		merged.IsFromBangkok, changed = *update.IsFromBangkok, true
+	}
+	if update.OriginLocation != nil {
+		merged.OriginLocation, changed = *update.OriginLocation, true
+	}
+	if update.AttendanceDates != nil {
+		merged.AttendanceDates, changed = update.AttendanceDates, true
+	}
+	if update.InterestedActivities != nil {
+		merged.InterestedActivities, changed = update.InterestedActivities, true
+	}
+	if update.DiscoveryChannel != nil {
+		merged.DiscoveryChannel, changed = update.DiscoveryChannel, true
+	}
+	if update.ExtraAttributes != nil {
+		changed = true
+	}
+
+	return &merged, changed
+}
+
+// storedExtraAttributes decodes the extra attributes stored for participantType into their field of
+// ExtraAttributesFields, so an update can keep them when it does not send them again.
+func storedExtraAttributes(participantType models.ParticipantType, raw json.RawMessage) (*models.ExtraAttributesFields, error) {
+	fields := &models.ExtraAttributesFields{}
+	if len(raw) == 0 {
+		return fields, nil
+	}
+
+	var target any
+	switch participantType {
+	case models.ParticipantTypeStudent:
+		fields.StudentExtraAttributes = &models.StudentExtraAttributes{}
+		target = fields.StudentExtraAttributes
+	case models.ParticipantTypeIntania:
+		fields.IntaniaExtraAttributes = &models.IntaniaExtraAttributes{}
+		target = fields.IntaniaExtraAttributes
+	case models.ParticipantTypeOutsideStudent:
+		fields.OutsideStudentExtraAttributes = &models.OutsideStudentExtraAttributes{}
+		target = fields.OutsideStudentExtraAttributes
+	case models.ParticipantTypeAlumni:
+		fields.AlumniExtraAttributes = &models.AlumniExtraAttributes{}
+		target = fields.AlumniExtraAttributes
+	case models.ParticipantTypeTeacher:
+		fields.TeacherExtraAttributes = &models.TeacherExtraAttributes{}
+		target = fields.TeacherExtraAttributes
+	default:
+		return fields, nil
+	}
+	if err := json.Unmarshal(raw, target); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// mergeExtraAttributes overlays the non-nil fields of update on fields.
+func mergeExtraAttributes(fields *models.ExtraAttributesFields, update *models.ExtraAttributesFields) {
+	if update == nil {
+		return
+	}
+	if update.StudentExtraAttributes != nil {
+		fields.StudentExtraAttributes = update.StudentExtraAttributes
+	}
+	if update.IntaniaExtraAttributes != nil {
+		fields.IntaniaExtraAttributes = update.IntaniaExtraAttributes
+	}
+	if update.OutsideStudentExtraAttributes != nil {
+		fields.OutsideStudentExtraAttributes = update.OutsideStudentExtraAttributes
+	}
+	if update.AlumniExtraAttributes != nil {
+		fields.AlumniExtraAttributes = update.AlumniExtraAttributes
+	}
+	if update.TeacherExtraAttributes != nil {
+		fields.TeacherExtraAttributes = update.TeacherExtraAttributes
+	}
+}
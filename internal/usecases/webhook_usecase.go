@@ -0,0 +1,302 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/config"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/webhook"
+)
+
+var (
+	ErrInvalidWebhookURL = errors.New("webhook url must be an absolute http or https url")
+	ErrInvalidEventType  = errors.New("invalid event type")
+)
+
+const (
+	webhookBatchSize = 20
+	// webhookLease must be longer than a batch of deliveries that all time out
+	webhookLease = 10 * time.Minute
+	// webhookResponseLimit is how much of a response body is read before the connection is reused
+	webhookResponseLimit = 64 << 10
+)
+
+// WebhookUsecase manages the webhook endpoints of partner systems and delivers domain events to them.
+type WebhookUsecase interface {
+	ListEndpoints(ctx context.Context) ([]models.WebhookEndpoint, error)
+	// CreateEndpoint generates the signing secret of the endpoint.
+	CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error
+	UpdateEndpoint(ctx context.Context, id int64, update *models.WebhookEndpointOptional) (*models.WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, endpointID int64, status models.WebhookDeliveryStatus, limit int) ([]models.WebhookDelivery, error)
+	ReplayDelivery(ctx context.Context, id int64) error
+
+	// HandleEvent queues deliveries of an event, it is meant to be subscribed to the EventDispatcher.
+	HandleEvent(ctx context.Context, event models.DomainEvent) error
+	// Run sends pending deliveries every poll interval until ctx is done.
+	Run(ctx context.Context)
+}
+
+type webhookUsecaseImpl struct {
+	repo          repositories.WebhookRepo
+	transactioner baserepo.Transactioner
+	cfg           config.Webhooks
+	client        *http.Client
+}
+
+func NewWebhookUsecase(repo repositories.WebhookRepo, transactioner baserepo.Transactioner, cfg config.Webhooks) WebhookUsecase {
+	return &webhookUsecaseImpl{
+		repo:          repo,
+		transactioner: transactioner,
+		cfg:           cfg,
+		client:        &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// webhookEnvelope is the body POSTed to endpoints.
+type webhookEnvelope struct {
+	ID         int64            `json:"id"`
+	Type       models.EventType `json:"type"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	Data       json.RawMessage  `json:"data"`
+}
+
+func (u *webhookUsecaseImpl) ListEndpoints(ctx context.Context) ([]models.WebhookEndpoint, error) {
+	ctx, span := tracer.Start(ctx, "WebhookUsecase.ListEndpoints")
+	defer span.End()
+
+	return u.repo.ListEndpoints(ctx)
+}
+
+func (u *webhookUsecaseImpl) CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	ctx, span := tracer.Start(ctx, "WebhookUsecase.CreateEndpoint")
+	defer span.End()
+
+	if err := validateWebhookEndpoint(endpoint); err != nil {
+		return err
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return err
+	}
+	endpoint.Secret = secret
+	endpoint.IsActive = true
+
+	return u.repo.CreateEndpoint(ctx, endpoint)
+}
+
+func (u *webhookUsecaseImpl) UpdateEndpoint(ctx context.Context, id int64, update *models.WebhookEndpointOptional) (*models.WebhookEndpoint, error) {
+	ctx, span := tracer.Start(ctx, "WebhookUsecase.UpdateEndpoint")
+	defer span.End()
+
+	var endpoint *models.WebhookEndpoint
+	err := u.transactioner.Transaction(ctx, func(ctx context.Context) error {
+		current, err := u.repo.GetEndpointForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		merged, changed := mergeWebhookEndpoint(current, update)
+		if !changed {
+			return ErrNothingToUpdate
+		}
+		if err := validateWebhookEndpoint(merged); err != nil {
+			return err
+		}
+
+		if err := u.repo.UpdateEndpoint(ctx, merged); err != nil {
+			return err
+		}
+		endpoint = merged
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+func (u *webhookUsecaseImpl) DeleteEndpoint(ctx context.Context, id int64) error {
+	ctx, span := tracer.Start(ctx, "WebhookUsecase.DeleteEndpoint")
+	defer span.End()
+
+	return u.repo.DeleteEndpoint(ctx, id)
+}
+
+func (u *webhookUsecaseImpl) ListDeliveries(ctx context.Context, endpointID int64, status models.WebhookDeliveryStatus, limit int) ([]models.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "WebhookUsecase.ListDeliveries")
+	defer span.End()
+
+	return u.repo.ListDeliveries(ctx, endpointID, status, limit)
+}
+
+func (u *webhookUsecaseImpl) ReplayDelivery(ctx context.Context, id int64) error {
+	ctx, span := tracer.Start(ctx, "WebhookUsecase.ReplayDelivery")
+	defer span.End()
+
+	return u.repo.ReplayDelivery(ctx, id)
+}
+
+func (u *webhookUsecaseImpl) HandleEvent(ctx context.Context, event models.DomainEvent) error {
+	ctx, span := tracer.Start(ctx, "WebhookUsecase.HandleEvent")
+	defer span.End()
+
+	body, err := json.Marshal(webhookEnvelope{
+		ID:         event.ID,
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt,
+		Data:       event.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.repo.EnqueueDeliveries(ctx, event.ID, event.Type, body)
+}
+
+func (u *webhookUsecaseImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(u.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Keep draining while full batches come back
+		for {
+			n, err := u.sendBatch(ctx)
+			if err != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "webhooks: send batch", "error", err)
+			}
+			if err != nil || n < webhookBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (u *webhookUsecaseImpl) sendBatch(ctx context.Context) (int, error) {
+	deliveries, err := u.repo.ClaimPendingDeliveries(ctx, webhookLease, webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, d := range deliveries {
+		statusCode, sendErr := u.send(ctx, d)
+		if sendErr == nil {
+			if err := u.repo.MarkDeliverySucceeded(ctx, d.ID, statusCode); err != nil {
+				return len(deliveries), err
+			}
+			continue
+		}
+
+		var code *int
+		if statusCode != 0 {
+			code = &statusCode
+		}
+		var retryAt *time.Time
+		if d.Attempts+1 < u.cfg.MaxAttempts {
+			next := time.Now().Add(webhookBackoff(d.Attempts))
+			retryAt = &next
+		}
+		if err := u.repo.MarkDeliveryFailed(ctx, d.ID, code, sendErr, retryAt); err != nil {
+			return len(deliveries), err
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// send returns the response status code, or 0 when no response was received.
+func (u *webhookUsecaseImpl) send(ctx context.Context, d models.PendingWebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "intania-openhouse-2026-webhooks")
+	req.Header.Set(webhook.HeaderEvent, string(d.EventType))
+	req.Header.Set(webhook.HeaderDelivery, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(d.Secret, timestamp, d.Payload))
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, webhookResponseLimit))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// webhookBackoff doubles from 30 seconds up to 6 hours.
+func webhookBackoff(attempts int) time.Duration {
+	return min(30*time.Second<<min(attempts, 10), 6*time.Hour)
+}
+
+func validateWebhookEndpoint(endpoint *models.WebhookEndpoint) error {
+	parsed, err := url.Parse(endpoint.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+
+	for _, t := range endpoint.EventTypes {
+		if !slices.Contains(models.EventTypes, models.EventType(t)) {
+			return ErrInvalidEventType
+		}
+	}
+	return nil
+}
+
+// mergeWebhookEndpoint applies the non-nil fields of update on a copy of current.
+func mergeWebhookEndpoint(current *models.WebhookEndpoint, update *models.WebhookEndpointOptional) (*models.WebhookEndpoint, bool) {
+	merged := *current
+	changed := false
+
+	if update.URL != nil {
+		merged.URL, changed = *update.URL, true
+	}
+	if update.EventTypes != nil {
+		merged.EventTypes, changed = eventTypeStrings(update.EventTypes), true
+	}
+	if update.Description != nil {
+		merged.Description, changed = *update.Description, true
+	}
+	if update.IsActive != nil {
+		merged.IsActive, changed = *update.IsActive, true
+	}
+
+	return &merged, changed
+}
+
+func eventTypeStrings(eventTypes []models.EventType) []string {
+	s := make([]string, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		s = append(s, string(t))
+	}
+	return s
+}
@@ -2,29 +2,65 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"sort"
 
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
 	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/baserepo"
+	"github.com/esc-chula/intania-openhouse-2026-api/pkg/myValidator"
+)
+
+var (
+	ErrInvalidWorkshopTime       = errors.New("end time must be after start time")
+	ErrInvalidTotalSeats         = errors.New("total seats must be at least 1")
+	ErrTotalSeatsBelowRegistered = errors.New("total seats cannot be less than registered count")
+	ErrWorkshopHasActiveBookings = errors.New("workshop still has active bookings")
+	ErrNothingToUpdate           = errors.New("nothing to update")
+	ErrInvalidBookingWindow      = errors.New("booking must close after it opens")
+	ErrInvalidSeatReleases       = errors.New("seat releases must have distinct times, increasing percents and end at 100 percent")
+	ErrInvalidParticipantRules   = errors.New("participant rules must have distinct types and reserve at most 100 percent in total")
+	ErrCustomParticipantRules    = errors.New("workshop has custom participant rules, set them again to change its category")
 )
 
 type WorkshopUsecase interface {
 	GetWorkshop(ctx context.Context, userEmail string, workshopId int64, fields []string) (*models.WorkshopDetail, error)
 	ListWorkshop(ctx context.Context, filter models.WorkshopFilter) ([]*models.Workshop, error)
+	// ListStaffWorkshops lists every workshop, the check-in code is left empty unless staff is an admin or
+	// is assigned to the workshop.
+	ListStaffWorkshops(ctx context.Context, staff StaffMember) ([]*models.Workshop, error)
+	CreateWorkshop(ctx context.Context, workshop *models.Workshop) error
+	UpdateWorkshop(ctx context.Context, workshopId int64, update *models.WorkshopOptional) (*models.Workshop, error)
+	DeleteWorkshop(ctx context.Context, workshopId int64) error
+	RotateCheckInCode(ctx context.Context, staff StaffMember, workshopId int64) (string, error)
+	GetSeatReleases(ctx context.Context, workshopId int64) ([]models.SeatRelease, error)
+	// SetSeatReleases replaces the seat release schedule, an empty schedule opens every seat at once.
+	SetSeatReleases(ctx context.Context, workshopId int64, releases []models.SeatRelease) ([]models.SeatRelease, error)
+	GetParticipantRules(ctx context.Context, workshopId int64) ([]models.ParticipantRule, error)
+	// SetParticipantRules replaces the participant rules, an empty list admits every participant type.
+	SetParticipantRules(ctx context.Context, workshopId int64, rules []models.ParticipantRule) ([]models.ParticipantRule, error)
 }
 
 type workshopUsecaseImpl struct {
-	workshopRepo repositories.WorkshopRepo
-	userRepo     repositories.UserRepo
+	workshopRepo  repositories.WorkshopRepo
+	userRepo      repositories.UserRepo
+	staffRepo     repositories.StaffRepo
+	transactioner baserepo.Transactioner
 }
 
-func NewWorkshopUsecase(workshopRepo repositories.WorkshopRepo, userRepo repositories.UserRepo) WorkshopUsecase {
+func NewWorkshopUsecase(workshopRepo repositories.WorkshopRepo, userRepo repositories.UserRepo, staffRepo repositories.StaffRepo, transactioner baserepo.Transactioner) WorkshopUsecase {
 	return &workshopUsecaseImpl{
-		workshopRepo: workshopRepo,
-		userRepo:     userRepo,
+		workshopRepo:  workshopRepo,
+		userRepo:      userRepo,
+		staffRepo:     staffRepo,
+		transactioner: transactioner,
 	}
 }
 
 func (u *workshopUsecaseImpl) GetWorkshop(ctx context.Context, userEmail string, workshopId int64, fields []string) (*models.WorkshopDetail, error) {
+	ctx, span := tracer.Start(ctx, "WorkshopUsecase.GetWorkshop")
+	defer span.End()
+
 	user, err := u.userRepo.GetUserByEmail(ctx, userEmail, []string{"id"})
 	if err != nil {
 		return nil, err
@@ -35,5 +71,362 @@ func (u *workshopUsecaseImpl) GetWorkshop(ctx context.Context, userEmail string,
 }
 
 func (u *workshopUsecaseImpl) ListWorkshop(ctx context.Context, filter models.WorkshopFilter) ([]*models.Workshop, error) {
+	ctx, span := tracer.Start(ctx, "WorkshopUsecase.ListWorkshop")
+	defer span.End()
+
 	return u.workshopRepo.ListWorkshop(ctx, filter)
 }
+
+func (u *workshopUsecaseImpl) ListStaffWorkshops(ctx context.Context, staff StaffMember) ([]*models.Workshop, error) {
+	ctx, span := tracer.Start(ctx, "WorkshopUsecase.ListStaffWorkshops")
+	defer span.End()
+
+	workshops, err := u.workshopRepo.ListWorkshop(ctx, models.WorkshopFilter{SortBy: "start_time", Order: "asc"})
+	if err != nil {
+		return nil, err
+	}
+	if staff.Admin {
+		return workshops, nil
+	}
+
+	ids, err := u.staffRepo.ListAssignedWorkshopIDs(ctx, normalizeEmail(staff.Email))
+	if err != nil {
+		return nil, err
+	}
+	assigned := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		assigned[id] = true
+	}
+	for _, w := range workshops {
+		if !assigned[w.ID] {
+			w.CheckInCode = ""
+		}
+	}
+	return workshops, nil
+}
+
+func (u *workshopUsecaseImpl) CreateWorkshop(ctx context.Context, workshop *models.Workshop) error {
+	ctx, span := tracer.Start(ctx, "WorkshopUsecase.CreateWorkshop")
+	defer span.End()
+
+	if err := myValidator.ValidateEventDate(workshop.EventDate); err != nil {
+		return err
+	}
+	if err := validateWorkshop(workshop); err != nil {
+		return err
+	}
+	return u.transactioner.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.workshopRepo.CreateWorkshop(ctx, workshop); err != nil {
+			return err
+		}
+		if err := u.workshopRepo.ReplaceParticipantRules(ctx, workshop.ID, defaultParticipantRules(workshop.Category)); err != nil {
+			return err
+		}
+		created, err := u.workshopRepo.GetWorkshopWithSeats(ctx, workshop.ID)
+		if err != nil {
+			return err
+		}
+		*workshop = *created
+		return nil
+	})
+}
+
+func (u *workshopUsecaseImpl) UpdateWorkshop(ctx context.Context, workshopId int64, update *models.WorkshopOptional) (*models.Workshop, error) {
+	ctx, span := tracer.Start(ctx, "WorkshopUsecase.UpdateWorkshop")
+	defer span.End()
+
+	if update.EventDate != nil {
+		if err := myValidator.ValidateEventDate(*update.EventDate); err != nil {
+			return nil, err
+		}
+	}
+
+	var workshop *models.Workshop
+	err := u.transactioner.Transaction(ctx, func(ctx context.Context) error {
+		// Lock the row so registered_count cannot change between validation and update
+		current, err := u.workshopRepo.GetWorkshopForUpdate(ctx, workshopId)
+		if err != nil {
+			return err
+		}
+
+		merged, changed := mergeWorkshop(current, update)
+		if !changed {
+			return ErrNothingToUpdate
+		}
+		if err := validateWorkshop(merged); err != nil {
+			return err
+		}
+
+		if err := u.workshopRepo.UpdateWorkshop(ctx, workshopId, update); err != nil {
+			return err
+		}
+		if merged.Category != current.Category {
+			if err := u.replaceDefaultParticipantRules(ctx, workshopId, current.Category, merged.Category); err != nil {
+				return err
+			}
+		}
+		workshop, err = u.workshopRepo.GetWorkshopWithSeats(ctx, workshopId)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return workshop, nil
+}
+
+func (u *workshopUsecaseImpl) DeleteWorkshop(ctx context.Context, workshopId int64) error {
+	ctx, span := tracer.Start(ctx, "WorkshopUsecase.DeleteWorkshop")
+	defer span.End()
+
+	return u.transactioner.Transaction(ctx, func(ctx context.Context) error {
+		current, err := u.workshopRepo.GetWorkshopForUpdate(ctx, workshopId)
+		if err != nil {
+			return err
+		}
+		// Bookings cascade on delete, so refuse rather than silently dropping attendees
+		if current.RegisteredCount > 0 {
+			return ErrWorkshopHasActiveBookings
+		}
+		return u.workshopRepo.DeleteWorkshop(ctx, workshopId)
+	})
+}
+
+func (u *workshopUsecaseImpl) RotateCheckInCode(ctx context.Context, staff StaffMember, workshopId int64) (string, error) {
+	ctx, span := tracer.Start(ctx, "WorkshopUsecase.RotateCheckInCode")
+	defer span.End()
+
+	if !staff.Admin {
+		assigned, err := u.staffRepo.IsAssigned(ctx, normalizeEmail(staff.Email), &workshopId, nil)
+		if err != nil {
+			return "", err
+		}
+		if !assigned {
+			return "", ErrStaffNotAssigned
+		}
+	}
+
+	return u.workshopRepo.RotateCheckInCode(ctx, workshopId)
+}
+
+func (u *workshopUsecaseImpl) GetSeatReleases(ctx context.Context, workshopId int64) ([]models.SeatRelease, error) {
+	ctx, span := tracer.Start(ctx, "WorkshopUsecase.GetSeatReleases")
+	defer span.End()
+
+	if _, err := u.workshopRepo.GetWorkshopById(ctx, workshopId, []string{"id"}); err != nil {
+		return nil, err
+	}
+	return u.workshopRepo.GetSeatReleases(ctx, workshopId)
+}
+
+func (u *workshopUsecaseImpl) SetSeatReleases(ctx context.Context, workshopId int64, releases []models.SeatRelease) ([]models.SeatRelease, error) {
+	ctx, span := tracer.Start(ctx, "WorkshopUsecase.SetSeatReleases")
+	defer span.End()
+
+	sort.Slice(releases, func(i, j int) bool { return releases[i].ReleaseAt.Before(releases[j].ReleaseAt) })
+	if err := validateSeatReleases(releases); err != nil {
+		return nil, err
+	}
+
+	err := u.transactioner.Transaction(ctx, func(ctx context.Context) error {
+		if _, err := u.workshopRepo.GetWorkshopForUpdate(ctx, workshopId); err != nil {
+			return err
+		}
+		return u.workshopRepo.ReplaceSeatReleases(ctx, workshopId, releases)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
+func (u *workshopUsecaseImpl) GetParticipantRules(ctx context.Context, workshopId int64) ([]models.ParticipantRule, error) {
+	ctx, span := tracer.Start(ctx, "WorkshopUsecase.GetParticipantRules")
+	defer span.End()
+
+	if _, err := u.workshopRepo.GetWorkshopById(ctx, workshopId, []string{"id"}); err != nil {
+		return nil, err
+	}
+	return u.workshopRepo.GetParticipantRules(ctx, workshopId)
+}
+
+func (u *workshopUsecaseImpl) SetParticipantRules(ctx context.Context, workshopId int64, rules []models.ParticipantRule) ([]models.ParticipantRule, error) {
+	ctx, span := tracer.Start(ctx, "WorkshopUsecase.SetParticipantRules")
+	defer span.End()
+
+	if err := validateParticipantRules(rules); err != nil {
+		return nil, err
+	}
+
+	err := u.transactioner.Transaction(ctx, func(ctx context.Context) error {
+		// Locking the workshop serialises the change with bookings, which lock the same row
+		if _, err := u.workshopRepo.GetWorkshopForUpdate(ctx, workshopId); err != nil {
+			return err
+		}
+		return u.workshopRepo.ReplaceParticipantRules(ctx, workshopId, rules)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func validateParticipantRules(rules []models.ParticipantRule) error {
+	seen := make(map[models.ParticipantType]bool, len(rules))
+	reserved := 0
+	for _, r := range rules {
+		if err := myValidator.ValidateParticipantType(string(r.ParticipantType)); err != nil {
+			return err
+		}
+		if seen[r.ParticipantType] {
+			return ErrInvalidParticipantRules
+		}
+		seen[r.ParticipantType] = true
+		if r.ReservedPercent != nil {
+			if *r.ReservedPercent < 1 || *r.ReservedPercent > 100 {
+				return ErrInvalidParticipantRules
+			}
+			reserved += *r.ReservedPercent
+		}
+	}
+	if reserved > 100 {
+		return ErrInvalidParticipantRules
+	}
+	return nil
+}
+
+// defaultParticipantRules are the rules a new workshop starts with, matching who each category was open to
+// before the rules became editable.
+func defaultParticipantRules(category models.WorkShopCategory) []models.ParticipantRule {
+	var types []models.ParticipantType
+	switch category {
+	case models.WorkShopCategoryDepartment:
+		types = []models.ParticipantType{models.ParticipantTypeStudent, models.ParticipantTypeIntania, models.ParticipantTypeOutsideStudent}
+	case models.WorkShopCategoryClub:
+		types = []models.ParticipantType{models.ParticipantTypeStudent}
+	}
+
+	rules := make([]models.ParticipantRule, 0, len(types))
+	for _, t := range types {
+		rules = append(rules, models.ParticipantRule{ParticipantType: t})
+	}
+	return rules
+}
+
+// replaceDefaultParticipantRules moves a workshop whose category changes to the default rules of the new
+// category. Rules edited by an admin are not guessed at, the change is refused until they are set again.
+func (u *workshopUsecaseImpl) replaceDefaultParticipantRules(ctx context.Context, workshopId int64, from, to models.WorkShopCategory) error {
+	rules, err := u.workshopRepo.GetParticipantRules(ctx, workshopId)
+	if err != nil {
+		return err
+	}
+	if !sameParticipantRules(rules, defaultParticipantRules(from)) {
+		return ErrCustomParticipantRules
+	}
+	return u.workshopRepo.ReplaceParticipantRules(ctx, workshopId, defaultParticipantRules(to))
+}
+
+// sameParticipantRules compares rules regardless of their order.
+func sameParticipantRules(a, b []models.ParticipantRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	reserved := make(map[models.ParticipantType]*int, len(a))
+	for _, r := range a {
+		reserved[r.ParticipantType] = r.ReservedPercent
+	}
+	for _, r := range b {
+		percent, ok := reserved[r.ParticipantType]
+		if !ok || (percent == nil) != (r.ReservedPercent == nil) || (percent != nil && *percent != *r.ReservedPercent) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateSeatReleases expects releases sorted by release time. Every seat must be released in the end.
+func validateSeatReleases(releases []models.SeatRelease) error {
+	for i, r := range releases {
+		if r.Percent < 1 || r.Percent > 100 {
+			return ErrInvalidSeatReleases
+		}
+		if i > 0 && (!r.ReleaseAt.After(releases[i-1].ReleaseAt) || r.Percent <= releases[i-1].Percent) {
+			return ErrInvalidSeatReleases
+		}
+	}
+	if len(releases) > 0 && releases[len(releases)-1].Percent != 100 {
+		return ErrInvalidSeatReleases
+	}
+	return nil
+}
+
+// mergeWorkshop applies the non-nil fields of update on a copy of current.
+func mergeWorkshop(current *models.Workshop, update *models.WorkshopOptional) (*models.Workshop, bool) {
+	merged := *current
+	changed := false
+
+	if update.Name != nil {
+		merged.Name, changed = *update.Name, true
+	}
+	if update.Description != nil {
+		merged.Description, changed = *update.Description, true
+	}
+	if update.Category != nil {
+		merged.Category, changed = *update.Category, true
+	}
+	if update.Affiliation != nil {
+		merged.Affiliation, changed = *update.Affiliation, true
+	}
+	if update.EventDate != nil {
+		merged.EventDate, changed = *update.EventDate, true
+	}
+	if update.StartTime != nil {
+		merged.StartTime, changed = *update.StartTime, true
+	}
+	if update.EndTime != nil {
+		merged.EndTime, changed = *update.EndTime, true
+	}
+	if update.Location != nil {
+		merged.Location, changed = *update.Location, true
+	}
+	if update.TotalSeats != nil {
+		merged.TotalSeats, changed = *update.TotalSeats, true
+	}
+	if update.Image != nil {
+		merged.Image, changed = *update.Image, true
+	}
+	if update.BookingOpensAt != nil {
+		merged.BookingOpensAt, changed = update.BookingOpensAt, true
+	}
+	if update.ClearBookingOpensAt {
+		merged.BookingOpensAt, changed = nil, true
+	}
+	if update.BookingClosesAt != nil {
+		merged.BookingClosesAt, changed = update.BookingClosesAt, true
+	}
+	if update.ClearBookingClosesAt {
+		merged.BookingClosesAt, changed = nil, true
+	}
+
+	return &merged, changed
+}
+
+// validateWorkshop checks the invariants that span several fields. The event date format is
+// validated by the callers since dates read back from Postgres are not in `YYYY-MM-DD` form.
+func validateWorkshop(workshop *models.Workshop) error {
+	if err := myValidator.ValidateWorkshopCategory(string(workshop.Category)); err != nil {
+		return err
+	}
+	if !workshop.EndTime.After(workshop.StartTime) {
+		return ErrInvalidWorkshopTime
+	}
+	if workshop.TotalSeats < 1 {
+		return ErrInvalidTotalSeats
+	}
+	if workshop.TotalSeats < workshop.RegisteredCount {
+		return ErrTotalSeatsBelowRegistered
+	}
+	if workshop.BookingOpensAt != nil && workshop.BookingClosesAt != nil && !workshop.BookingClosesAt.After(*workshop.BookingOpensAt) {
+		return ErrInvalidBookingWindow
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/models"
+	"github.com/esc-chula/intania-openhouse-2026-api/internal/repositories"
+)
+
+// fakeRulesWorkshopRepo holds one workshop and its participant rules.
+type fakeRulesWorkshopRepo struct {
+	repositories.WorkshopRepo
+	workshop models.Workshop
+	rules    []models.ParticipantRule
+}
+
+func (r *fakeRulesWorkshopRepo) GetWorkshopForUpdate(ctx context.Context, id int64) (*models.Workshop, error) {
+	w := r.workshop
+	return &w, nil
+}
+
+func (r *fakeRulesWorkshopRepo) UpdateWorkshop(ctx context.Context, id int64, update *models.WorkshopOptional) error {
+	if update.Category != nil {
+		r.workshop.Category = *update.Category
+	}
+	return nil
+}
+
+func (r *fakeRulesWorkshopRepo) GetWorkshopWithSeats(ctx context.Context, id int64) (*models.Workshop, error) {
+	w := r.workshop
+	return &w, nil
+}
+
+func (r *fakeRulesWorkshopRepo) GetParticipantRules(ctx context.Context, workshopID int64) ([]models.ParticipantRule, error) {
+	return r.rules, nil
+}
+
+func (r *fakeRulesWorkshopRepo) ReplaceParticipantRules(ctx context.Context, workshopID int64, rules []models.ParticipantRule) error {
+	r.rules = rules
+	return nil
+}
+
+func TestUpdateWorkshopCategoryRules(t *testing.T) {
+	club := models.WorkShopCategoryClub
+	reserved := 20
+
+	tests := []struct {
+		name      string
+		rules     []models.ParticipantRule
+		wantErr   error
+		wantRules []models.ParticipantRule
+	}{
+		{
+			name:      "default rules follow the new category",
+			rules:     defaultParticipantRules(models.WorkShopCategoryDepartment),
+			wantRules: defaultParticipantRules(club),
+		},
+		{
+			name: "custom rules refuse the change",
+			rules: []models.ParticipantRule{
+				{ParticipantType: models.ParticipantTypeStudent, ReservedPercent: &reserved},
+				{ParticipantType: models.ParticipantTypeTeacher},
+			},
+			wantErr: ErrCustomParticipantRules,
+		},
+		{
+			name:    "cleared rules refuse the change",
+			rules:   []models.ParticipantRule{},
+			wantErr: ErrCustomParticipantRules,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRulesWorkshopRepo{
+				workshop: models.Workshop{ID: 1, Category: models.WorkShopCategoryDepartment, TotalSeats: 10,
+					StartTime: time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC), EndTime: time.Date(0, 1, 1, 10, 0, 0, 0, time.UTC)},
+				rules: tt.rules,
+			}
+			u := NewWorkshopUsecase(repo, nil, nil, fakeTransactioner{})
+
+			_, err := u.UpdateWorkshop(context.Background(), 1, &models.WorkshopOptional{Category: &club})
+			if err != tt.wantErr {
+				t.Fatalf("UpdateWorkshop() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(repo.rules, tt.wantRules) {
+				t.Errorf("participant rules = %+v, want %+v", repo.rules, tt.wantRules)
+			}
+		})
+	}
+}
@@ -38,7 +38,12 @@ func NewTransactioner(db *bun.DB) Transactioner {
 	return &transactionerImpl{db}
 }
 
+// Transaction joins the transaction already in ctx if any, so usecases can be composed.
 func (e *transactionerImpl) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(dbContextKey{}).(bun.Tx); ok {
+		return fn(ctx)
+	}
+
 	return e.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
 		return fn(context.WithValue(ctx, dbContextKey{}, tx))
 	})
@@ -4,27 +4,52 @@ import (
 	"bytes"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
 )
 
+// DevTokenSecretKey is the token secret of .env.example, it is public so it is rejected in production.
+const DevTokenSecretKey = "local-development-secret-do-not-use-in-production"
+
 type Config interface {
 	App() App
 	Database() Database
 	Firebase() Firebase
+	Token() Token
+	Stats() Stats
+	Notifier() Notifier
+	Events() Events
+	Webhooks() Webhooks
+	Sweeper() Sweeper
+	BookingPolicy() BookingPolicy
+	Idempotency() Idempotency
+	RateLimit() RateLimit
+	Metrics() Metrics
+	Tracing() Tracing
 
 	String() string
 }
 
+// App configures the HTTP server. ReadHeaderTimeout, ReadTimeout, WriteTimeout and IdleTimeout bound every
+// connection, RequestTimeout is the context deadline of an operation and must stay below WriteTimeout.
+// Streaming operations are exempt from both. On SIGTERM in-flight requests get ShutdownTimeout to finish.
 type App struct {
-	Address        string   `mapstructure:"address"         validate:"required"`
-	AllowedOrigins []string `mapstructure:"allowed_origins"`
-	IsProduction   bool     `mapstructure:"is_production"`
+	Address           string        `mapstructure:"address"             validate:"required"`
+	AllowedOrigins    []string      `mapstructure:"allowed_origins"`
+	IsProduction      bool          `mapstructure:"is_production"`
+	ReadHeaderTimeout time.Duration `mapstructure:"read_header_timeout" validate:"required"`
+	ReadTimeout       time.Duration `mapstructure:"read_timeout"        validate:"required"`
+	WriteTimeout      time.Duration `mapstructure:"write_timeout"       validate:"required,gtfield=RequestTimeout"`
+	IdleTimeout       time.Duration `mapstructure:"idle_timeout"        validate:"required"`
+	RequestTimeout    time.Duration `mapstructure:"request_timeout"     validate:"required"`
+	ShutdownTimeout   time.Duration `mapstructure:"shutdown_timeout"    validate:"required"`
 }
 
 type Database struct {
@@ -35,17 +60,154 @@ type Firebase struct {
 	ServiceAccountKeyFile string `mapstructure:"service_account_key_file"`
 }
 
+// Token configures the tokens signed by this API (e.g. attendee passes), not the Firebase ID tokens.
+// SecretKey has no default, Validate checks it only for serve so the other subcommands run without it.
+type Token struct {
+	SecretKey       string        `mapstructure:"secret_key"        json:"-"`
+	AttendeePassTTL time.Duration `mapstructure:"attendee_pass_ttl" validate:"required"`
+}
+
+// Validate checks that SecretKey is at least 32 bytes and is not DevTokenSecretKey in production.
+func (t Token) Validate(isProduction bool) error {
+	if len(t.SecretKey) < 32 {
+		return errors.New("TOKEN_SECRET_KEY must be at least 32 bytes")
+	}
+	if isProduction && t.SecretKey == DevTokenSecretKey {
+		return errors.New("TOKEN_SECRET_KEY is the development key of .env.example, set a random one in production")
+	}
+	return nil
+}
+
+// Stats configures the admin dashboard aggregates, they are recomputed at most once per CacheTTL.
+type Stats struct {
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+}
+
+// Notifier configures the booking notifications, they are only logged when SMTPHost is empty.
+type Notifier struct {
+	SMTPHost     string        `mapstructure:"smtp_host"`
+	SMTPPort     int           `mapstructure:"smtp_port"`
+	SMTPUsername string        `mapstructure:"smtp_username"`
+	SMTPPassword string        `mapstructure:"smtp_password" json:"-"`
+	From         string        `mapstructure:"from"          validate:"required"`
+	PollInterval time.Duration `mapstructure:"poll_interval" validate:"required"`
+	ReminderLead time.Duration `mapstructure:"reminder_lead" validate:"required"`
+	MaxAttempts  int           `mapstructure:"max_attempts"  validate:"required"`
+}
+
+// Events configures the dispatcher of domain events to in-process subscribers.
+type Events struct {
+	PollInterval time.Duration `mapstructure:"poll_interval" validate:"required"`
+	MaxAttempts  int           `mapstructure:"max_attempts"  validate:"required"`
+}
+
+// Webhooks configures the delivery of domain events to the webhook endpoints of partner systems.
+type Webhooks struct {
+	PollInterval time.Duration `mapstructure:"poll_interval" validate:"required"`
+	MaxAttempts  int           `mapstructure:"max_attempts"  validate:"required"`
+	Timeout      time.Duration `mapstructure:"timeout"       validate:"required"`
+}
+
+// Sweeper configures the background job that marks Confirmed bookings of ended workshops as Absent.
+type Sweeper struct {
+	Interval time.Duration `mapstructure:"interval" validate:"required"`
+}
+
+// BookingPolicy limits attendee bookings, a zero value disables the rule. Bookings cannot be cancelled
+// within CancellationCutoff of the workshop start, a user holds at most MaxConfirmedBookings bookings of
+// workshops that have not ended, and cannot book anymore after MaxAbsences no-shows. A group leader holds
+// at most MaxGroupSize seats of a workshop across all of their group bookings.
+type BookingPolicy struct {
+	CancellationCutoff   time.Duration `mapstructure:"cancellation_cutoff"`
+	MaxConfirmedBookings int           `mapstructure:"max_confirmed_bookings"`
+	MaxAbsences          int           `mapstructure:"max_absences"`
+	MaxGroupSize         int           `mapstructure:"max_group_size"`
+}
+
+// Idempotency configures how long the first response to a request with an Idempotency-Key header is
+// replayed, expired keys are purged every PurgeInterval.
+type Idempotency struct {
+	TTL           time.Duration `mapstructure:"ttl"            validate:"required"`
+	PurgeInterval time.Duration `mapstructure:"purge_interval" validate:"required"`
+}
+
+// RateLimit configures the token buckets of the hot endpoints. Backend is postgres, shared by every instance,
+// or memory for a single instance during development. With TrustProxy the client IP is read from
+// X-Forwarded-For, ProxyHops entries from the end: every proxy appends the address it received the request
+// from, so the entry appended by the outermost trusted proxy is the client and earlier entries are set by the
+// client itself. Cloud Run alone is one hop, an external load balancer in front of it adds one. A user who
+// enters more than MaxInvalidCheckIns invalid codes within InvalidCheckInWindow is locked out of check-in for
+// CheckInLockout, the lock is held by the per-user check-in bucket so it needs CheckIn.PerUser.
+type RateLimit struct {
+	Backend              string        `mapstructure:"backend"                 validate:"oneof=postgres memory"`
+	TrustProxy           bool          `mapstructure:"trust_proxy"`
+	ProxyHops            int           `mapstructure:"proxy_hops"              validate:"required_if=TrustProxy true,omitempty,min=1"`
+	Book                 RateLimitRule `mapstructure:"book"`
+	CheckIn              RateLimitRule `mapstructure:"check_in"`
+	Redemption           RateLimitRule `mapstructure:"redemption"`
+	MaxInvalidCheckIns   int           `mapstructure:"max_invalid_check_ins"`
+	InvalidCheckInWindow time.Duration `mapstructure:"invalid_check_in_window"`
+	CheckInLockout       time.Duration `mapstructure:"check_in_lockout"`
+	PurgeInterval        time.Duration `mapstructure:"purge_interval"          validate:"required"`
+}
+
+// RateLimitRule allows PerUser requests per Firebase UID and PerIP requests per client IP every Period,
+// with bursts up to the same numbers. A zero limit disables that bucket.
+type RateLimitRule struct {
+	PerUser int           `mapstructure:"per_user"`
+	PerIP   int           `mapstructure:"per_ip"`
+	Period  time.Duration `mapstructure:"period"`
+}
+
+// Metrics configures GET /metrics, when Token is set Prometheus must send it as a bearer token.
+type Metrics struct {
+	Token string `mapstructure:"token" json:"-"`
+}
+
+// Tracing configures OpenTelemetry tracing. Exporter is none, stdout to print the spans during development, or
+// otlp to send them over OTLP/HTTP to Endpoint (host:port). SampleRatio is the fraction of the traces started
+// by this API that are recorded, a trace started by the caller follows the caller's decision.
+type Tracing struct {
+	Exporter    string  `mapstructure:"exporter"     validate:"oneof=none stdout otlp"`
+	Endpoint    string  `mapstructure:"endpoint"     validate:"required_if=Exporter otlp"`
+	Insecure    bool    `mapstructure:"insecure"`
+	ServiceName string  `mapstructure:"service_name" validate:"required"`
+	SampleRatio float64 `mapstructure:"sample_ratio" validate:"gte=0,lte=1"`
+}
+
 // -------------------------------------------------------------------------- //
 
 type config struct {
-	AppCfg      App      `mapstructure:"app"`
-	DatabaseCfg Database `mapstructure:"database"`
-	FirebaseCfg Firebase `mapstructure:"firebase"`
+	AppCfg           App           `mapstructure:"app"`
+	DatabaseCfg      Database      `mapstructure:"database"`
+	FirebaseCfg      Firebase      `mapstructure:"firebase"`
+	TokenCfg         Token         `mapstructure:"token"`
+	StatsCfg         Stats         `mapstructure:"stats"`
+	NotifierCfg      Notifier      `mapstructure:"notifier"`
+	EventsCfg        Events        `mapstructure:"events"`
+	WebhooksCfg      Webhooks      `mapstructure:"webhooks"`
+	SweeperCfg       Sweeper       `mapstructure:"sweeper"`
+	BookingPolicyCfg BookingPolicy `mapstructure:"booking_policy"`
+	IdempotencyCfg   Idempotency   `mapstructure:"idempotency"`
+	RateLimitCfg     RateLimit     `mapstructure:"rate_limit"`
+	MetricsCfg       Metrics       `mapstructure:"metrics"`
+	TracingCfg       Tracing       `mapstructure:"tracing"`
 }
 
-func (c *config) App() App           { return c.AppCfg }
-func (c *config) Database() Database { return c.DatabaseCfg }
-func (c *config) Firebase() Firebase { return c.FirebaseCfg }
+func (c *config) App() App                     { return c.AppCfg }
+func (c *config) Database() Database           { return c.DatabaseCfg }
+func (c *config) Firebase() Firebase           { return c.FirebaseCfg }
+func (c *config) Token() Token                 { return c.TokenCfg }
+func (c *config) Stats() Stats                 { return c.StatsCfg }
+func (c *config) Notifier() Notifier           { return c.NotifierCfg }
+func (c *config) Events() Events               { return c.EventsCfg }
+func (c *config) Webhooks() Webhooks           { return c.WebhooksCfg }
+func (c *config) Sweeper() Sweeper             { return c.SweeperCfg }
+func (c *config) BookingPolicy() BookingPolicy { return c.BookingPolicyCfg }
+func (c *config) Idempotency() Idempotency     { return c.IdempotencyCfg }
+func (c *config) RateLimit() RateLimit         { return c.RateLimitCfg }
+func (c *config) Metrics() Metrics             { return c.MetricsCfg }
+func (c *config) Tracing() Tracing             { return c.TracingCfg }
 
 func (c *config) String() string {
 	jsonBytes, err := json.MarshalIndent(c, "", "  ")
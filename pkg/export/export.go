@@ -0,0 +1,138 @@
+package export
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// Writer writes a table row by row. Close must be called to flush the output, Abort releases the writer
+// without completing the output when the export fails halfway.
+type Writer interface {
+	WriteRow(row []string) error
+	Close() error
+	Abort()
+}
+
+func NewWriter(format Format, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w)
+	case FormatXLSX:
+		return newXLSXWriter(w)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+func ContentType(format Format) string {
+	switch format {
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// utf8BOM lets Excel detect that the CSV is UTF-8, it shows Thai names garbled otherwise.
+const utf8BOM = "\ufeff"
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return nil, err
+	}
+	return &csvWriter{w: csv.NewWriter(w)}, nil
+}
+
+func (c *csvWriter) WriteRow(row []string) error {
+	cells := make([]string, len(row))
+	for i, v := range row {
+		cells[i] = escapeFormula(v)
+	}
+	return c.w.Write(cells)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// Abort drops the buffered rows, the ones already flushed cannot be taken back.
+func (c *csvWriter) Abort() {}
+
+// escapeFormula prefixes a cell that a spreadsheet would run as a formula with a quote, so values entered
+// by users such as names are shown as text. XLSX cells are written as strings and need no escaping.
+func escapeFormula(v string) string {
+	if v == "" {
+		return v
+	}
+	switch v[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + v
+	}
+	return v
+}
+
+// xlsxWriter uses the excelize stream writer so rows are not kept as cell objects,
+// the workbook itself can only be written to w once it is complete.
+type xlsxWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	rowNum int
+}
+
+const xlsxSheet = "Sheet1"
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter(xlsxSheet)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &xlsxWriter{out: w, file: file, stream: stream}, nil
+}
+
+func (x *xlsxWriter) WriteRow(row []string) error {
+	x.rowNum++
+	cell, err := excelize.CoordinatesToCellName(1, x.rowNum)
+	if err != nil {
+		return err
+	}
+
+	values := make([]any, len(row))
+	for i, v := range row {
+		values[i] = v
+	}
+	return x.stream.SetRow(cell, values)
+}
+
+// Abort discards the workbook, nothing has been written to out yet.
+func (x *xlsxWriter) Abort() {
+	x.file.Close()
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	_, err := x.file.WriteTo(x.out)
+	return err
+}
@@ -0,0 +1,37 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCSVWriterEscapesFormulas(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewWriter(FormatCSV, &out)
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	rows := [][]string{
+		{"first_name", "phone"},
+		{"=HYPERLINK(\"http://example.com\")", "+66812345678"},
+		{"สมชาย", "@SUM(A1)"},
+		{"-1", ""},
+	}
+	for _, row := range rows {
+		if err := w.WriteRow(row); err != nil {
+			t.Fatalf("WriteRow() error = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	want := utf8BOM +
+		"first_name,phone\n" +
+		"\"'=HYPERLINK(\"\"http://example.com\"\")\",'+66812345678\n" +
+		"สมชาย,'@SUM(A1)\n" +
+		"'-1,\n"
+	if got := out.String(); got != want {
+		t.Errorf("csv output = %q, want %q", got, want)
+	}
+}
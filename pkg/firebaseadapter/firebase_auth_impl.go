@@ -7,9 +7,13 @@ import (
 	firebase "firebase.google.com/go/v4"
 	"firebase.google.com/go/v4/auth"
 	"github.com/esc-chula/intania-openhouse-2026-api/pkg/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
 	"google.golang.org/api/option"
 )
 
+var tracer = otel.Tracer("github.com/esc-chula/intania-openhouse-2026-api/pkg/firebaseadapter")
+
 type firebaseAuthImpl struct {
 	auth *auth.Client
 }
@@ -38,8 +42,13 @@ func (f *firebaseAuthImpl) VerifyIDToken(
 	ctx context.Context,
 	idToken string,
 ) (*TokenInfo, error) {
+	// The public keys are cached by the Firebase client, the span shows when they are fetched again
+	ctx, span := tracer.Start(ctx, "FirebaseAdapter.VerifyIDToken")
+	defer span.End()
+
 	token, err := f.auth.VerifyIDToken(ctx, idToken)
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		return nil, err
 	}
 
@@ -0,0 +1,142 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ContentType = "text/calendar; charset=utf-8"
+
+	// maxLineOctets is the longest content line allowed before folding, excluding the CRLF
+	maxLineOctets = 75
+)
+
+// Event times can be in any location, they are written local to the calendar location.
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Location    string
+	Description string
+	URL         string
+}
+
+// Calendar is an RFC 5545 calendar written with a VTIMEZONE for Location and every event time local to it.
+// The location must not observe daylight saving time, its offset is read once.
+type Calendar struct {
+	ProdID   string
+	Name     string
+	Location *time.Location
+	Events   []Event
+}
+
+// Write writes the calendar with CRLF line endings and long lines folded.
+func (c *Calendar) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	lw := &lineWriter{w: bw}
+
+	tzid := c.Location.String()
+	name, offset := time.Now().In(c.Location).Zone()
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	lw.line("BEGIN:VCALENDAR")
+	lw.line("VERSION:2.0")
+	lw.line("PRODID:" + c.ProdID)
+	lw.line("CALSCALE:GREGORIAN")
+	lw.line("METHOD:PUBLISH")
+	if c.Name != "" {
+		lw.line("X-WR-CALNAME:" + escapeText(c.Name))
+	}
+	lw.line("X-WR-TIMEZONE:" + tzid)
+
+	lw.line("BEGIN:VTIMEZONE")
+	lw.line("TZID:" + tzid)
+	lw.line("BEGIN:STANDARD")
+	lw.line("DTSTART:19700101T000000")
+	lw.line("TZOFFSETFROM:" + formatOffset(offset))
+	lw.line("TZOFFSETTO:" + formatOffset(offset))
+	if name != tzid {
+		lw.line("TZNAME:" + name)
+	}
+	lw.line("END:STANDARD")
+	lw.line("END:VTIMEZONE")
+
+	for _, e := range c.Events {
+		lw.line("BEGIN:VEVENT")
+		lw.line("UID:" + e.UID)
+		lw.line("DTSTAMP:" + stamp)
+		lw.line("DTSTART;TZID=" + tzid + ":" + e.Start.In(c.Location).Format("20060102T150405"))
+		lw.line("DTEND;TZID=" + tzid + ":" + e.End.In(c.Location).Format("20060102T150405"))
+		lw.line("SUMMARY:" + escapeText(e.Summary))
+		if e.Location != "" {
+			lw.line("LOCATION:" + escapeText(e.Location))
+		}
+		if e.Description != "" {
+			lw.line("DESCRIPTION:" + escapeText(e.Description))
+		}
+		if e.URL != "" {
+			lw.line("URL:" + e.URL)
+		}
+		lw.line("END:VEVENT")
+	}
+
+	lw.line("END:VCALENDAR")
+	if lw.err != nil {
+		return lw.err
+	}
+	return bw.Flush()
+}
+
+// lineWriter folds content lines longer than maxLineOctets without splitting UTF-8 sequences.
+// The first error is kept and later writes are skipped.
+type lineWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (lw *lineWriter) line(s string) {
+	if lw.err != nil {
+		return
+	}
+
+	n := 0
+	for len(s) > 0 {
+		_, size := utf8.DecodeRuneInString(s)
+		if n+size > maxLineOctets {
+			// The leading space of the continuation line counts towards its length
+			if _, lw.err = lw.w.WriteString("\r\n "); lw.err != nil {
+				return
+			}
+			n = 1
+		}
+		if _, lw.err = lw.w.WriteString(s[:size]); lw.err != nil {
+			return
+		}
+		n += size
+		s = s[size:]
+	}
+	_, lw.err = lw.w.WriteString("\r\n")
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", "")
+
+// escapeText escapes a TEXT property value.
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// formatOffset formats a UTC offset in seconds as +HHMM.
+func formatOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, offset/3600, offset%3600/60)
+}
@@ -25,3 +25,8 @@ func ParseAuthToken(secret []byte, tokenString string) (jwt.MapClaims, error) {
 
 	return nil, errors.New("invalid token")
 }
+
+// GenerateToken signs the claims with HS256 so that they can be read back with ParseAuthToken.
+func GenerateToken(secret []byte, claims jwt.MapClaims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+}
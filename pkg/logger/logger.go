@@ -0,0 +1,145 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/danielgtaylor/huma/v2"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Error categories derived from the response status.
+const (
+	CategoryValidation = "validation"
+	CategoryDomain     = "domain"
+	CategoryInternal   = "internal"
+)
+
+// New returns a JSON logger that adds the request attributes and the trace found in the context to every record.
+// Debug records, such as every SQL query, are only written when debug is set.
+func New(w io.Writer, debug bool) *slog.Logger {
+	level := slog.LevelInfo
+	if debug {
+		level = slog.LevelDebug
+	}
+	return slog.New(&contextHandler{
+		Handler: slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}),
+	})
+}
+
+type requestInfoKey struct{}
+
+// RequestInfo is shared by everything handling a request, so attributes learned deep in the stack,
+// like the authenticated user, show up in the request log written once the response is sent.
+type RequestInfo struct {
+	mu    sync.Mutex
+	id    string
+	uid   string
+	email string
+	err   error
+}
+
+// WithRequest starts the log attributes of a request.
+func WithRequest(ctx context.Context, requestID string) (context.Context, *RequestInfo) {
+	info := &RequestInfo{id: requestID}
+	return context.WithValue(ctx, requestInfoKey{}, info), info
+}
+
+func requestInfo(ctx context.Context) *RequestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(*RequestInfo)
+	return info
+}
+
+// RequestID returns the ID of the request ctx belongs to, or an empty string outside a request.
+func RequestID(ctx context.Context) string {
+	if info := requestInfo(ctx); info != nil {
+		return info.id
+	}
+	return ""
+}
+
+// SetUser records the authenticated user of the request.
+func SetUser(ctx context.Context, uid string, email string) {
+	if info := requestInfo(ctx); info != nil {
+		info.mu.Lock()
+		defer info.mu.Unlock()
+		info.uid = uid
+		info.email = email
+	}
+}
+
+// SetError records the error the request failed with.
+func SetError(ctx context.Context, err error) {
+	if info := requestInfo(ctx); info != nil {
+		info.mu.Lock()
+		defer info.mu.Unlock()
+		info.err = err
+	}
+}
+
+// Error returns the error recorded with SetError.
+func (i *RequestInfo) Error() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.err
+}
+
+func (i *RequestInfo) attrs() []slog.Attr {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	attrs := []slog.Attr{slog.String("request_id", i.id)}
+	if i.uid != "" {
+		attrs = append(attrs, slog.String("uid", i.uid))
+	}
+	if i.email != "" {
+		attrs = append(attrs, slog.String("email", i.email))
+	}
+	return attrs
+}
+
+// ErrorCategory classifies a failed response. Huma rejects malformed requests with 422, or a problem
+// carrying field details, every other client error comes from a domain rule.
+func ErrorCategory(status int, err error) string {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return CategoryInternal
+	case status == http.StatusUnprocessableEntity:
+		return CategoryValidation
+	}
+
+	var model *huma.ErrorModel
+	if errors.As(err, &model) && len(model.Errors) > 0 {
+		return CategoryValidation
+	}
+	return CategoryDomain
+}
+
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if info := requestInfo(ctx); info != nil {
+		record.AddAttrs(info.attrs()...)
+	}
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", spanCtx.TraceID().String()),
+			slog.String("span_id", spanCtx.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
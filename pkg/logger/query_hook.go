@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// QueryHook logs SQL queries with the request attributes of their context. Like bundebug it logs every
+// query when verbose, at debug level, and otherwise only the failed ones.
+type QueryHook struct {
+	verbose bool
+}
+
+var _ bun.QueryHook = (*QueryHook)(nil)
+
+func NewQueryHook(verbose bool) *QueryHook {
+	return &QueryHook{verbose: verbose}
+}
+
+func (h *QueryHook) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	return ctx
+}
+
+func (h *QueryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	failed := event.Err != nil && !errors.Is(event.Err, sql.ErrNoRows) && !errors.Is(event.Err, sql.ErrTxDone)
+	if !failed && !h.verbose {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", event.Operation()),
+		slog.String("query", event.Query),
+		slog.Float64("duration_ms", float64(time.Since(event.StartTime))/float64(time.Millisecond)),
+	}
+	level := slog.LevelDebug
+	if failed {
+		level = slog.LevelError
+		attrs = append(attrs, slog.String("error", event.Err.Error()))
+	}
+	slog.Default().LogAttrs(ctx, level, "query", attrs...)
+}
@@ -17,6 +17,7 @@ var (
 	ErrInvalidTransportMode    = errors.New("invalid transport mode")
 	ErrInvalidOriginLocation   = errors.New("invalid origin location")
 	ErrInvalidWorkshopCategory = errors.New("invalid workshop category")
+	ErrInvalidBoothCategory    = errors.New("invalid booth category")
 )
 
 type EnumOption struct {
@@ -30,6 +31,7 @@ type Enums struct {
 	TransportModes     []EnumOption `json:"transport_modes"`
 	OriginLocations    []EnumOption `json:"origin_locations"`
 	WorkshopCategories []EnumOption `json:"workshop_categories"`
+	BoothCategories    []EnumOption `json:"booth_categories"`
 }
 
 var (
@@ -38,6 +40,7 @@ var (
 	validTransportModes     map[string]bool
 	validOriginLocations    map[string]bool
 	validWorkshopCategories map[string]bool
+	validBoothCategories    map[string]bool
 	loadedEnums             Enums
 )
 
@@ -50,6 +53,7 @@ func init() {
 	validTransportModes = buildValidMap(loadedEnums.TransportModes)
 	validOriginLocations = buildValidMap(loadedEnums.OriginLocations)
 	validWorkshopCategories = buildValidMap(loadedEnums.WorkshopCategories)
+	validBoothCategories = buildValidMap(loadedEnums.BoothCategories)
 }
 
 func buildValidMap(options []EnumOption) map[string]bool {
@@ -81,3 +85,17 @@ func ValidateWorkshopCategory(category string) error {
 	}
 	return nil
 }
+
+func ValidateBoothCategory(category string) error {
+	if !validBoothCategories[category] {
+		return ErrInvalidBoothCategory
+	}
+	return nil
+}
+
+func ValidateParticipantType(participantType string) error {
+	if !validParticipantTypes[participantType] {
+		return ErrInvalidParticipantType
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ var (
 	ErrExtraAttributesRequired = errors.New("extra attributes required")
 	ErrExtraAttributesInvalid  = errors.New("extra attributes invalid")
 	ErrInvalidEventDate        = errors.New("invalid event date format, expected YYYY-MM-DD")
+	ErrInvalidTimeOfDay        = errors.New("invalid time format, expected HH:MM")
 )
 
 var validate = validator.New()
@@ -80,3 +81,12 @@ func ValidateEventDate(date string) error {
 	}
 	return nil
 }
+
+// ParseTimeOfDay parses a `HH:MM` string into a time.Time suitable for TIME columns.
+func ParseTimeOfDay(value string) (time.Time, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return time.Time{}, ErrInvalidTimeOfDay
+	}
+	return t, nil
+}
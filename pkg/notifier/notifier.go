@@ -0,0 +1,30 @@
+package notifier
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Message is channel agnostic, To is an address understood by the Notifier it is given to
+// (an email address for SMTP, a user ID for a chat channel).
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type logNotifier struct{}
+
+// NewLogNotifier returns a Notifier that only logs messages, for environments without a mail server.
+func NewLogNotifier() Notifier {
+	return logNotifier{}
+}
+
+func (logNotifier) Send(ctx context.Context, msg Message) error {
+	slog.InfoContext(ctx, "notifier: message", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	return nil
+}
@@ -0,0 +1,61 @@
+package pglisten
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	minBackoff = 500 * time.Millisecond
+	maxBackoff = 30 * time.Second
+)
+
+// Listen runs LISTEN on channel over a dedicated connection and calls handle for every notification
+// until ctx is done. A lost connection is re-established with exponential backoff, onReconnect is called
+// after every successful (re)connect so callers can resync state that may have been missed meanwhile.
+func Listen(ctx context.Context, dsn string, channel string, handle func(payload string), onReconnect func()) {
+	backoff := minBackoff
+	for ctx.Err() == nil {
+		err := listenOnce(ctx, dsn, channel, handle, func() {
+			backoff = minBackoff
+			if onReconnect != nil {
+				onReconnect()
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+
+		slog.ErrorContext(ctx, "pglisten: connection lost, reconnecting", "channel", channel, "backoff", backoff.String(), "error", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+func listenOnce(ctx context.Context, dsn string, channel string, handle func(payload string), onConnect func()) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return err
+	}
+	onConnect()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		handle(notification.Payload)
+	}
+}
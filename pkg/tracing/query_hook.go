@@ -0,0 +1,73 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/esc-chula/intania-openhouse-2026-api/pkg/tracing")
+
+type querySpanKey struct{}
+
+// QueryHook records a client span for every SQL query run within a traced request or usecase, the polling
+// queries of the background workers are not traced. bun inlines the arguments in the query, so the query
+// text, which holds user data, is only recorded when recordQuery is set.
+type QueryHook struct {
+	recordQuery bool
+}
+
+var _ bun.QueryHook = (*QueryHook)(nil)
+
+func NewQueryHook(recordQuery bool) *QueryHook {
+	return &QueryHook{recordQuery: recordQuery}
+}
+
+func (h *QueryHook) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+
+	operation := event.Operation()
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemNamePostgreSQL,
+		semconv.DBOperationName(operation),
+	}
+	name := operation
+	if event.IQuery != nil {
+		if table := event.IQuery.GetTableName(); table != "" {
+			attrs = append(attrs, semconv.DBCollectionName(table))
+			name += " " + table
+		}
+	}
+	if h.recordQuery {
+		attrs = append(attrs, semconv.DBQueryText(event.Query))
+	}
+
+	ctx, span := tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(event.StartTime),
+		trace.WithAttributes(attrs...),
+	)
+	return context.WithValue(ctx, querySpanKey{}, span)
+}
+
+func (h *QueryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	span, ok := ctx.Value(querySpanKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	if event.Err != nil && !errors.Is(event.Err, sql.ErrNoRows) {
+		span.RecordError(event.Err)
+		span.SetStatus(codes.Error, event.Err.Error())
+	}
+}
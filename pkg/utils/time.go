@@ -0,0 +1,16 @@
+package utils
+
+import "time"
+
+// BangkokLocation is the event timezone. Thailand has no daylight saving time,
+// so a fixed zone avoids depending on tzdata in the container image.
+var BangkokLocation = time.FixedZone("Asia/Bangkok", 7*60*60)
+
+// FormatDate formats a DATE column, which is scanned as an RFC3339 string, as `2006-01-02`.
+func FormatDate(date string) string {
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return date
+	}
+	return t.Format(time.DateOnly)
+}